@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// abiCacheEntry is a parsed ABI along with the file state it was parsed from
+type abiCacheEntry struct {
+	modTime time.Time
+	size    int64
+	parsed  abi.ABI
+}
+
+var (
+	abiCacheMu sync.Mutex
+	abiCache   = make(map[string]abiCacheEntry)
+)
+
+// loadABI reads and parses the ABI file at path. The parsed ABI is reused across calls in the
+// same process until the file's modification time or size changes.
+func loadABI(path string) (abi.ABI, error) {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("failed to read ABI: %w", err)
+	}
+
+	abiCacheMu.Lock()
+	entry, ok := abiCache[path]
+	abiCacheMu.Unlock()
+	if ok && entry.modTime.Equal(info.ModTime()) && entry.size == info.Size() {
+		return entry.parsed, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("failed to read ABI: %w", err)
+	}
+	parsed, err := parseABI(data)
+	if err != nil {
+		return abi.ABI{}, err
+	}
+
+	abiCacheMu.Lock()
+	abiCache[path] = abiCacheEntry{modTime: info.ModTime(), size: info.Size(), parsed: parsed}
+	abiCacheMu.Unlock()
+	return parsed, nil
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// findMethodOutputs returns the outputs of the ABI method called name that takes nargs
+// arguments, so overloads resolve the same way the call itself does
+func findMethodOutputs(parsedABI abi.ABI, name string, nargs int) (abi.Arguments, bool) {
+	for _, method := range parsedABI.Methods {
+		if method.RawName == name && len(method.Inputs) == nargs {
+			return method.Outputs, true
+		}
+	}
+	return nil, false
+}
+
+// decodeOutputsJSON unpacks return data against outputs into a JSON object keyed by output
+// name, or by position for unnamed outputs
+func decodeOutputsJSON(outputs abi.Arguments, data []byte) (map[string]interface{}, error) {
+	values, err := outputs.Unpack(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode result as %s: %w", formatDecodeTypes(outputs), err)
+	}
+
+	out := make(map[string]interface{}, len(values))
+	for i, value := range values {
+		key := outputs[i].Name
+		if key == "" {
+			key = strconv.Itoa(i)
+		}
+		out[key] = abiJSONValue(outputs[i].Type, reflect.ValueOf(value))
+	}
+	return out, nil
+}
+
+// abiJSONValue converts a decoded ABI value into a JSON-friendly form: addresses, big integers
+// and byte strings become strings, arrays become JSON arrays, and tuples become objects
+func abiJSONValue(t abi.Type, v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		if n, ok := v.Interface().(*big.Int); ok {
+			return n.String()
+		}
+		return v.Interface()
+	case abi.AddressTy:
+		return v.Interface().(common.Address).Hex()
+	case abi.BytesTy:
+		return fmt.Sprintf("0x%x", v.Bytes())
+	case abi.FixedBytesTy, abi.HashTy:
+		b := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(b), v)
+		return fmt.Sprintf("0x%x", b)
+	case abi.SliceTy, abi.ArrayTy:
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = abiJSONValue(*t.Elem, v.Index(i))
+		}
+		return items
+	case abi.TupleTy:
+		if v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+		fields := make(map[string]interface{}, len(t.TupleElems))
+		for i, elem := range t.TupleElems {
+			key := t.TupleRawNames[i]
+			if key == "" {
+				key = strconv.Itoa(i)
+			}
+			fields[key] = abiJSONValue(*elem, v.Field(i))
+		}
+		return fields
+	default:
+		return v.Interface()
+	}
+}
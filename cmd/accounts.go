@@ -1,12 +1,24 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/ethtypes"
+	"github.com/parthshah1/mpool-tx/config"
 	"github.com/urfave/cli/v2"
 )
 
@@ -33,6 +45,10 @@ var AccountsCmd = &cli.Command{
 					Usage: "Fund accounts with FIL",
 					Value: true,
 				},
+				&cli.StringFlag{
+					Name:  "min-balance",
+					Usage: "Fund new accounts to this balance (FIL) and top up existing roles below it (default: new accounts get 10 FIL, existing roles are skipped)",
+				},
 			},
 			Action: createAccounts,
 		},
@@ -45,9 +61,50 @@ var AccountsCmd = &cli.Command{
 					Usage:    "Workspace directory",
 					Required: true,
 				},
+				&cli.BoolFlag{
+					Name:  "json",
+					Usage: "Output accounts as JSON",
+				},
+				&cli.BoolFlag{
+					Name:  "show-private-key",
+					Usage: "Include unmasked private keys in JSON output",
+				},
 			},
 			Action: listAccounts,
 		},
+		{
+			Name:  "balance",
+			Usage: "Show the FIL (and optionally token) balance of every role",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "workspace",
+					Usage:    "Workspace directory",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  "token",
+					Usage: "Deployed token contract to include a balance column for (e.g. USDFC)",
+				},
+			},
+			Action: accountBalances,
+		},
+		{
+			Name:  "rotate",
+			Usage: "Replace a role's key with a fresh account, sweeping its FIL to the new address",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "workspace",
+					Usage:    "Workspace directory",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "role",
+					Usage:    "Role to rotate (or any of its addresses)",
+					Required: true,
+				},
+			},
+			Action: rotateAccount,
+		},
 	},
 }
 
@@ -56,6 +113,16 @@ func createAccounts(c *cli.Context) error {
 	roles := c.StringSlice("role")
 	fund := c.Bool("fund")
 
+	fundAmount := types.FromFil(10)
+	topUp := c.IsSet("min-balance")
+	if topUp {
+		minBalance, err := types.ParseFIL(c.String("min-balance"))
+		if err != nil {
+			return fmt.Errorf("invalid --min-balance: %w", err)
+		}
+		fundAmount = types.BigInt(minBalance)
+	}
+
 	accountsPath := filepath.Join(workspace, "accounts.json")
 
 	accounts := AccountsFile{Accounts: make(map[string]AccountInfo)}
@@ -71,8 +138,14 @@ func createAccounts(c *cli.Context) error {
 	}
 
 	for _, role := range roles {
-		if _, exists := accounts.Accounts[role]; exists {
-			fmt.Printf("Account '%s' already exists, skipping\n", role)
+		if info, exists := accounts.Accounts[role]; exists {
+			if !topUp || !fund {
+				fmt.Printf("Account '%s' already exists, skipping\n", role)
+				continue
+			}
+			if err := topUpAccount(c.Context, role, info, fundAmount); err != nil {
+				return err
+			}
 			continue
 		}
 
@@ -81,19 +154,21 @@ func createAccounts(c *cli.Context) error {
 			return fmt.Errorf("failed to create account for role '%s': %w", role, err)
 		}
 
+		info := AccountInfo{
+			Address:    filAddr.String(),
+			EthAddress: config.ChecksumAddress(ethAddr.String()),
+			PrivateKey: fmt.Sprintf("0x%x", key.PrivateKey),
+		}
+
 		if fund {
-			fundAmount := types.FromFil(10)
 			_, err := FundWallet(c.Context, filAddr, fundAmount, true)
 			if err != nil {
 				return fmt.Errorf("failed to fund %s: %w", role, err)
 			}
+			info = recordIDAddress(c.Context, info)
 		}
 
-		accounts.Accounts[role] = AccountInfo{
-			Address:    filAddr.String(),
-			EthAddress: ethAddr.String(),
-			PrivateKey: fmt.Sprintf("0x%x", key.PrivateKey),
-		}
+		accounts.Accounts[role] = info
 
 		fmt.Printf("Created '%s': %s (ETH: %s)\n", role, filAddr, ethAddr)
 	}
@@ -111,6 +186,96 @@ func createAccounts(c *cli.Context) error {
 	return nil
 }
 
+// topUpAccount funds an existing role with the difference between its balance and minBalance,
+// leaving roles that already hold at least minBalance alone
+func topUpAccount(ctx context.Context, role string, info AccountInfo, minBalance types.BigInt) error {
+	addr, err := address.NewFromString(info.Address)
+	if err != nil {
+		return fmt.Errorf("invalid address for '%s': %w", role, err)
+	}
+
+	balance, err := GetBalance(ctx, addr)
+	if err != nil {
+		return fmt.Errorf("failed to get balance of %s: %w", role, err)
+	}
+	if balance.GreaterThanEqual(minBalance) {
+		fmt.Printf("Account '%s' already exists with %s, skipping\n", role, types.FIL(balance))
+		return nil
+	}
+
+	shortfall := types.BigSub(minBalance, balance)
+	if _, err := FundWallet(ctx, addr, shortfall, true); err != nil {
+		return fmt.Errorf("failed to top up %s: %w", role, err)
+	}
+	fmt.Printf("Topped up '%s' by %s to %s\n", role, types.FIL(shortfall), types.FIL(minBalance))
+	return nil
+}
+
+// rotateAccount gives a role a newly generated key. The old account's balance is swept to the
+// new one before accounts.json is updated, so a failed sweep leaves the role unchanged.
+func rotateAccount(c *cli.Context) error {
+	ctx := c.Context
+	workspace := c.String("workspace")
+
+	accounts, err := loadAccounts(workspace)
+	if err != nil {
+		return err
+	}
+
+	role, old, exists := findAccount(ctx, accounts, c.String("role"))
+	if !exists {
+		return fmt.Errorf("account role '%s' not found", c.String("role"))
+	}
+
+	oldKey, err := parsePrivateKey(old.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("invalid private key for '%s': %w", role, err)
+	}
+	oldEth, err := ethtypes.ParseEthAddress(old.EthAddress)
+	if err != nil {
+		return fmt.Errorf("invalid eth address for '%s': %w", role, err)
+	}
+
+	k, ethAddr, filAddr, err := NewAccount()
+	if err != nil {
+		return fmt.Errorf("failed to create account for role '%s': %w", role, err)
+	}
+
+	fmt.Printf("Rotating '%s'\n", role)
+	fmt.Printf("  Old: %s (ETH: %s)\n", old.Address, old.EthAddress)
+	fmt.Printf("  New: %s (ETH: %s)\n", filAddr, config.ChecksumAddress(ethAddr.String()))
+
+	amount, txHash, err := SweepAccount(ctx, crypto.FromECDSA(oldKey), oldEth, ethAddr)
+	if err != nil {
+		return fmt.Errorf("failed to sweep '%s' (accounts.json left unchanged): %w", role, err)
+	}
+	if amount.IsZero() {
+		fmt.Println("Old account holds no FIL, nothing to sweep")
+	} else {
+		fmt.Printf("Swept %s to the new account (tx %s)\n", types.FIL(amount), txHash)
+	}
+
+	info := AccountInfo{
+		Address:    filAddr.String(),
+		EthAddress: config.ChecksumAddress(ethAddr.String()),
+		PrivateKey: fmt.Sprintf("0x%x", k.PrivateKey),
+	}
+	accounts.Accounts[role] = recordIDAddress(ctx, info)
+
+	data, err := json.MarshalIndent(accounts, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal accounts: %w", err)
+	}
+
+	accountsPath := filepath.Join(workspace, "accounts.json")
+	if err := os.WriteFile(accountsPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write accounts file: %w", err)
+	}
+
+	fmt.Printf("Updated '%s' in %s\n", role, accountsPath)
+	return nil
+}
+
 func listAccounts(c *cli.Context) error {
 	workspace := c.String("workspace")
 	accountsPath := filepath.Join(workspace, "accounts.json")
@@ -124,13 +289,290 @@ func listAccounts(c *cli.Context) error {
 	if err := json.Unmarshal(data, &accounts); err != nil {
 		return fmt.Errorf("failed to parse accounts file: %w", err)
 	}
+	accounts.normalize()
+
+	if jsonOutput(c) {
+		showKeys := c.Bool("show-private-key")
+		out := make(map[string]AccountInfo, len(accounts.Accounts))
+		for role, info := range accounts.Accounts {
+			if !showKeys {
+				info.PrivateKey = maskPrivateKey(info.PrivateKey)
+			}
+			out[role] = info
+		}
+
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal accounts: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
 
 	for role, info := range accounts.Accounts {
 		fmt.Printf("%s:\n", role)
 		fmt.Printf("  Filecoin: %s\n", info.Address)
+		if info.IDAddress != "" {
+			fmt.Printf("  ID:       %s\n", info.IDAddress)
+		}
 		fmt.Printf("  Ethereum: %s\n", info.EthAddress)
 		fmt.Printf("  PrivKey:  %s\n\n", info.PrivateKey)
 	}
 
 	return nil
 }
+
+// normalize makes the delegated address each account's identity. An account stored under its
+// ID address keeps it as IDAddress, with the delegated address derived from its Ethereum address.
+func (a *AccountsFile) normalize() {
+	for role, info := range a.Accounts {
+		addr, err := address.NewFromString(info.Address)
+		if err != nil || addr.Protocol() == address.Delegated || info.EthAddress == "" {
+			continue
+		}
+
+		delegated, err := delegatedAddress(info.EthAddress)
+		if err != nil {
+			continue
+		}
+		if addr.Protocol() == address.ID && info.IDAddress == "" {
+			info.IDAddress = addr.String()
+		}
+		info.Address = delegated.String()
+		a.Accounts[role] = info
+	}
+}
+
+// Find returns the account for ref, which may be a role name or any of the account's delegated,
+// ID, or Ethereum addresses
+func (a *AccountsFile) Find(ref string) (string, AccountInfo, bool) {
+	if info, ok := a.Accounts[ref]; ok {
+		return ref, info, true
+	}
+
+	if common.IsHexAddress(ref) {
+		for role, info := range a.Accounts {
+			if strings.EqualFold(info.EthAddress, ref) {
+				return role, info, true
+			}
+		}
+		return "", AccountInfo{}, false
+	}
+
+	addr, err := address.NewFromString(ref)
+	if err != nil {
+		return "", AccountInfo{}, false
+	}
+	for role, info := range a.Accounts {
+		if sameAddress(info.Address, addr) || sameAddress(info.IDAddress, addr) {
+			return role, info, true
+		}
+	}
+	return "", AccountInfo{}, false
+}
+
+// findAccount is Find, additionally resolving an ID address that is not recorded in accounts.json
+// to its delegated address on chain
+func findAccount(ctx context.Context, accounts *AccountsFile, ref string) (string, AccountInfo, bool) {
+	if role, info, ok := accounts.Find(ref); ok {
+		return role, info, true
+	}
+
+	addr, err := address.NewFromString(ref)
+	if err != nil || addr.Protocol() != address.ID || clientt == nil {
+		return "", AccountInfo{}, false
+	}
+	keyAddr, err := clientt.GetAPI().StateAccountKey(ctx, addr, types.EmptyTSK)
+	if err != nil {
+		return "", AccountInfo{}, false
+	}
+	return accounts.Find(keyAddr.String())
+}
+
+// recordIDAddress looks up the account's ID address, which exists once the account has been
+// funded or has transacted. The account is returned unchanged if it has none yet.
+func recordIDAddress(ctx context.Context, info AccountInfo) AccountInfo {
+	addr, err := address.NewFromString(info.Address)
+	if err != nil || clientt == nil {
+		return info
+	}
+	id, err := clientt.GetAPI().StateLookupID(ctx, addr, types.EmptyTSK)
+	if err != nil {
+		return info
+	}
+	info.IDAddress = id.String()
+	return info
+}
+
+// sameAddress reports whether the stored address string refers to addr, regardless of network prefix
+func sameAddress(stored string, addr address.Address) bool {
+	if stored == "" {
+		return false
+	}
+	parsed, err := address.NewFromString(stored)
+	return err == nil && parsed == addr
+}
+
+// delegatedAddress returns the f410 address of an Ethereum address
+func delegatedAddress(ethAddr string) (address.Address, error) {
+	ea, err := ethtypes.ParseEthAddress(ethAddr)
+	if err != nil {
+		return address.Undef, fmt.Errorf("invalid Ethereum address %s: %w", ethAddr, err)
+	}
+	return ea.ToFilecoinAddress()
+}
+
+// maskPrivateKey hides all but the first and last few characters of a private key
+func maskPrivateKey(privateKey string) string {
+	if len(privateKey) <= 10 {
+		return strings.Repeat("*", len(privateKey))
+	}
+	return privateKey[:6] + "..." + privateKey[len(privateKey)-4:]
+}
+
+// roleBalance is one row of `accounts balance`
+type roleBalance struct {
+	Role     string
+	Account  AccountInfo
+	FIL      types.BigInt
+	FILErr   error
+	Token    string
+	TokenErr error
+}
+
+func accountBalances(c *cli.Context) error {
+	workspace := c.String("workspace")
+	token := c.String("token")
+
+	accounts, err := loadAccounts(workspace)
+	if err != nil {
+		return fmt.Errorf("failed to load accounts: %w", err)
+	}
+	if len(accounts.Accounts) == 0 {
+		fmt.Println("No accounts found")
+		return nil
+	}
+
+	roles := make([]string, 0, len(accounts.Accounts))
+	for role := range accounts.Accounts {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+
+	rows, err := lookupRoleBalances(c.Context, workspace, roles, accounts, token)
+	if err != nil {
+		return err
+	}
+
+	printRoleBalances(c.App.Writer, rows, token)
+	return nil
+}
+
+// lookupRoleBalances fetches FIL balances and, if token is set, token balances for every
+// role. FIL lookups run concurrently with the token lookups, which share one multicall.
+func lookupRoleBalances(ctx context.Context, workspace string, roles []string, accounts *AccountsFile, token string) ([]roleBalance, error) {
+	rows := make([]roleBalance, len(roles))
+	addrs := make([]address.Address, len(roles))
+	for i, role := range roles {
+		account := accounts.Accounts[role]
+		addr, err := address.NewFromString(account.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address for account '%s': %w", role, err)
+		}
+		rows[i] = roleBalance{Role: role, Account: account}
+		addrs[i] = addr
+	}
+
+	var queries []balanceQuery
+	var client *ethclient.Client
+	var multicall string
+	if token != "" {
+		wcfg, err := loadWorkspaceConfig(ctx, workspace)
+		if err != nil {
+			return nil, err
+		}
+		deployments, err := loadDeployments(workspace)
+		if err != nil {
+			return nil, err
+		}
+		for i := range rows {
+			q, err := newBalanceQuery(deployments, token, common.HexToAddress(rows[i].Account.EthAddress))
+			if err != nil {
+				return nil, err
+			}
+			queries = append(queries, q)
+		}
+
+		client, err = config.DialEthClient(wcfg.RPC, wcfg.Token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to RPC: %w", err)
+		}
+		defer client.Close()
+		multicall = wcfg.Multicall3
+	}
+
+	var wg sync.WaitGroup
+	var filBalances []WalletBalance
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		filBalances = GetBalances(ctx, addrs)
+	}()
+
+	var tokenResults [][]byte
+	var tokenErr error
+	if len(queries) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tokenResults, tokenErr = callBalanceQueries(ctx, client, multicall, queries, nil)
+		}()
+	}
+	wg.Wait()
+
+	for i := range rows {
+		rows[i].FIL, rows[i].FILErr = filBalances[i].Balance, filBalances[i].Err
+		if len(queries) == 0 {
+			continue
+		}
+		if tokenErr != nil {
+			rows[i].TokenErr = tokenErr
+			continue
+		}
+		balance, err := queries[i].unpack(tokenResults[i])
+		if err != nil {
+			rows[i].TokenErr = err
+			continue
+		}
+		rows[i].Token = formatTokenBalance(balance)
+	}
+
+	return rows, nil
+}
+
+func printRoleBalances(out io.Writer, rows []roleBalance, token string) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if token != "" {
+		fmt.Fprintf(w, "ROLE\tADDRESS\tFIL\t%s\n", token)
+	} else {
+		fmt.Fprintln(w, "ROLE\tADDRESS\tFIL")
+	}
+
+	for _, row := range rows {
+		fil := types.FIL(row.FIL).Unitless()
+		if row.FILErr != nil {
+			fil = fmt.Sprintf("error: %v", row.FILErr)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s", row.Role, row.Account.EthAddress, fil)
+
+		if token != "" {
+			tokenBalance := row.Token
+			if row.TokenErr != nil {
+				tokenBalance = fmt.Sprintf("error: %v", row.TokenErr)
+			}
+			fmt.Fprintf(w, "\t%s", tokenBalance)
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+}
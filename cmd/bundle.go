@@ -0,0 +1,295 @@
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// bundleManifestName is the archive entry holding per-file checksums
+const bundleManifestName = "MANIFEST.json"
+
+// BundleManifest records the checksum of every file in a workspace bundle
+type BundleManifest struct {
+	CreatedAt time.Time         `json:"created_at"`
+	Files     map[string]string `json:"files"` // relative path -> sha256 hex
+}
+
+func exportWorkspace(c *cli.Context) error {
+	workspace := c.String("workspace")
+	out := c.String("out")
+
+	info, err := os.Stat(workspace)
+	if err != nil {
+		return fmt.Errorf("failed to read workspace: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("workspace %s is not a directory", workspace)
+	}
+
+	manifest, err := writeWorkspaceBundle(workspace, out)
+	if err != nil {
+		return err
+	}
+
+	sum, err := fileSHA256(out)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported %d files from %s to %s\n", len(manifest.Files), workspace, out)
+	fmt.Printf("Bundle SHA-256: %s\n", sum)
+	return nil
+}
+
+func importBundle(c *cli.Context) error {
+	bundle := c.String("bundle")
+	workspace := c.String("workspace")
+
+	if entries, err := os.ReadDir(workspace); err == nil && len(entries) > 0 && !c.Bool("force") {
+		return fmt.Errorf("workspace %s is not empty (use --force to overwrite)", workspace)
+	}
+
+	if sum := c.String("sha256"); sum != "" {
+		actual, err := fileSHA256(bundle)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(actual, sum) {
+			return fmt.Errorf("bundle checksum mismatch: expected %s, got %s", sum, actual)
+		}
+	}
+
+	manifest, err := extractWorkspaceBundle(bundle, workspace)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %d files from %s into %s (checksums verified)\n", len(manifest.Files), bundle, workspace)
+	return nil
+}
+
+// writeWorkspaceBundle archives every regular file under workspace into a gzipped tarball with a checksum manifest
+func writeWorkspaceBundle(workspace, out string) (*BundleManifest, error) {
+	var paths []string
+	err := filepath.Walk(workspace, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk workspace: %w", err)
+	}
+	sort.Strings(paths)
+
+	absOut, _ := filepath.Abs(out)
+
+	f, err := os.Create(out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bundle: %w", err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	manifest := &BundleManifest{
+		CreatedAt: time.Now().UTC(),
+		Files:     make(map[string]string),
+	}
+
+	for _, path := range paths {
+		// Skip the bundle itself when it is written inside the workspace
+		if abs, _ := filepath.Abs(path); abs == absOut {
+			continue
+		}
+
+		rel, err := filepath.Rel(workspace, path)
+		if err != nil {
+			return nil, err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == bundleManifestName {
+			continue
+		}
+
+		sum, err := addFileToTar(tw, path, rel)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add %s: %w", rel, err)
+		}
+		manifest.Files[rel] = sum
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    bundleManifestName,
+		Mode:    0644,
+		Size:    int64(len(manifestData)),
+		ModTime: manifest.CreatedAt,
+	}); err != nil {
+		return nil, err
+	}
+	if _, err := tw.Write(manifestData); err != nil {
+		return nil, err
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize bundle: %w", err)
+	}
+
+	return manifest, nil
+}
+
+func addFileToTar(tw *tar.Writer, path, name string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return "", err
+	}
+	header.Name = name
+
+	if err := tw.WriteHeader(header); err != nil {
+		return "", err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tw, hash), f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// extractWorkspaceBundle restores a bundle into workspace and verifies every file against the manifest
+func extractWorkspaceBundle(bundle, workspace string) (*BundleManifest, error) {
+	f, err := os.Open(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle: %w", err)
+	}
+	defer gz.Close()
+
+	if err := os.MkdirAll(workspace, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %w", err)
+	}
+
+	var manifest *BundleManifest
+	sums := make(map[string]string)
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundle entry: %w", err)
+		}
+
+		if header.Name == bundleManifestName {
+			manifest = &BundleManifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, fmt.Errorf("failed to parse manifest: %w", err)
+			}
+			continue
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		target := filepath.Join(workspace, filepath.FromSlash(header.Name))
+		if rel, err := filepath.Rel(workspace, target); err != nil || strings.HasPrefix(rel, "..") {
+			return nil, fmt.Errorf("bundle entry %s escapes the workspace", header.Name)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, err
+		}
+
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode).Perm())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", target, err)
+		}
+
+		hash := sha256.New()
+		_, err = io.Copy(io.MultiWriter(out, hash), tr)
+		out.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", target, err)
+		}
+
+		sums[header.Name] = hex.EncodeToString(hash.Sum(nil))
+	}
+
+	if manifest == nil {
+		return nil, fmt.Errorf("bundle has no %s", bundleManifestName)
+	}
+
+	for name, expected := range manifest.Files {
+		actual, ok := sums[name]
+		if !ok {
+			return nil, fmt.Errorf("bundle is missing %s", name)
+		}
+		if actual != expected {
+			return nil, fmt.Errorf("checksum mismatch for %s", name)
+		}
+	}
+	for name := range sums {
+		if _, ok := manifest.Files[name]; !ok {
+			return nil, fmt.Errorf("unexpected file %s not listed in manifest", name)
+		}
+	}
+
+	return manifest, nil
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
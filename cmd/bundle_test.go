@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWorkspaceBundleRoundTrip(t *testing.T) {
+	files := map[string]string{
+		"deployments.json":                   `[{"name":"Token","address":"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"}]`,
+		"accounts.json":                      `{"accounts":{}}`,
+		"contracts/Token.abi.json":           `[]`,
+		"contracts/nested/Registry.abi.json": `[{"type":"function","name":"register"}]`,
+	}
+
+	workspace := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(workspace, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The bundle is written inside the workspace, and must not include itself
+	bundle := filepath.Join(workspace, "workspace.tar.gz")
+	exported, err := writeWorkspaceBundle(workspace, bundle)
+	if err != nil {
+		t.Fatalf("writeWorkspaceBundle: %v", err)
+	}
+	if len(exported.Files) != len(files) {
+		t.Errorf("manifest lists %d files, want %d", len(exported.Files), len(files))
+	}
+
+	restored := filepath.Join(t.TempDir(), "restored")
+	imported, err := extractWorkspaceBundle(bundle, restored)
+	if err != nil {
+		t.Fatalf("extractWorkspaceBundle: %v", err)
+	}
+	if !reflect.DeepEqual(imported.Files, exported.Files) {
+		t.Errorf("imported manifest = %v, want %v", imported.Files, exported.Files)
+	}
+
+	got := make(map[string]string)
+	err = filepath.Walk(restored, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(restored, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		got[filepath.ToSlash(rel)] = string(data)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk restored workspace: %v", err)
+	}
+	if !reflect.DeepEqual(got, files) {
+		t.Errorf("restored workspace = %v, want %v", got, files)
+	}
+}
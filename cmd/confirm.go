@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// confirmDestructive shows what an irreversible command is about to do and asks the user to
+// confirm. It returns nil when --yes is set or the user agrees, and an error otherwise.
+func confirmDestructive(c *cli.Context, summary string, items []string) error {
+	if c.Bool("yes") {
+		return nil
+	}
+
+	ok, err := promptConfirm(c.App.Reader, c.App.Writer, summary, items)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("aborted")
+	}
+	return nil
+}
+
+// promptConfirm prints the summary and items and reads a y/N answer. Anything other than
+// "y" or "yes", including end of input, counts as a refusal.
+func promptConfirm(in io.Reader, out io.Writer, summary string, items []string) (bool, error) {
+	fmt.Fprintln(out, summary)
+	for _, item := range items {
+		fmt.Fprintf(out, "  %s\n", item)
+	}
+	fmt.Fprint(out, "Are you sure? [y/N]: ")
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
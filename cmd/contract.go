@@ -1,11 +1,14 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
+	"encoding/csv"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math/big"
 	"os"
 	"os/exec"
@@ -15,6 +18,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/filecoin-project/go-address"
@@ -31,23 +35,29 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
-func waitForTransactionReceipt(ctx context.Context, api api.FullNode, txHash ethtypes.EthHash) (*api.EthTxReceipt, error) {
-	for i := 0; i < 60; i++ {
-		receipt, err := api.EthGetTransactionReceipt(ctx, txHash)
-		if err == nil && receipt != nil {
-			if receipt.Status == 1 {
-				fmt.Printf("Transaction confirmed: %s\n", txHash.String())
-				return receipt, nil
-			} else {
-				return nil, fmt.Errorf("transaction failed: %s", txHash.String())
-			}
+// waitForTransactionReceipt polls for txHash's receipt, writing progress to out
+func waitForTransactionReceipt(ctx context.Context, out io.Writer, node api.FullNode, txHash ethtypes.EthHash) (*api.EthTxReceipt, error) {
+	// Lotus returns a nil receipt without an error while the transaction is pending
+	receipt, err := config.PollReceipt(ctx, func(ctx context.Context) (*api.EthTxReceipt, bool, error) {
+		receipt, err := node.EthGetTransactionReceipt(ctx, txHash)
+		if err != nil {
+			return nil, false, err
 		}
-
-		fmt.Printf("Waiting for transaction confirmation... %s\n", txHash.String())
-		time.Sleep(1 * time.Second)
+		if receipt == nil {
+			fmt.Fprintf(out, "Waiting for transaction confirmation... %s\n", txHash.String())
+			return nil, false, nil
+		}
+		return receipt, true, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, fmt.Errorf("transaction not confirmed after waiting")
+	if receipt.Status != 1 {
+		return nil, fmt.Errorf("transaction failed: %s", txHash.String())
+	}
+	fmt.Fprintf(out, "Transaction confirmed: %s\n", txHash.String())
+	return receipt, nil
 }
 
 func SignTransaction(tx *ethtypes.Eth1559TxArgs, privateKey []byte) error {
@@ -77,8 +87,36 @@ func SubmitTransaction(ctx context.Context, api api.FullNode, tx ethtypes.EthTra
 	return txHash, nil
 }
 
-func DeployContract(ctx context.Context, contractPath string, deployer string, fundAmount string, generateBindings bool, workspace string, contractName string, abiPath string) error {
-	fmt.Printf("Deploying smart contract from %s...\n", contractPath)
+// chooseNonce returns override when set, or current otherwise. An override below the account's
+// current nonce would replace an already-sent transaction, so it requires force.
+func chooseNonce(current uint64, override *uint64, force bool) (uint64, error) {
+	if override == nil {
+		return current, nil
+	}
+	if *override < current && !force {
+		return 0, fmt.Errorf("nonce %d is below the account's current nonce %d (pass --force to use it anyway)", *override, current)
+	}
+	return *override, nil
+}
+
+// deployResult is what `contract deploy` reports, printed as JSON with --json
+type deployResult struct {
+	Name         string `json:"name,omitempty"`
+	Address      string `json:"address,omitempty"`
+	Deployer     string `json:"deployer,omitempty"`
+	DeployerEth  string `json:"deployerEth,omitempty"`
+	TxHash       string `json:"txHash,omitempty"`
+	GasUsed      uint64 `json:"gasUsed,omitempty"`
+	BytecodePath string `json:"bytecodePath,omitempty"`
+	ABIPath      string `json:"abiPath,omitempty"`
+	BindingsPath string `json:"bindingsPath,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+func DeployContract(ctx context.Context, out io.Writer, contractPath string, deployer string, fundAmount string, generateBindings bool, workspace string, contractName string, abiPath string, bindingsDir string, bindingsPkg string, constructorArgs []byte, nonceOverride *uint64, forceNonce bool) (*deployResult, error) {
+	fmt.Fprintf(out, "Deploying smart contract from %s...\n", contractPath)
+
+	result := &deployResult{Name: contractName}
 
 	var key *key.Key
 	var ethAddr ethtypes.EthAddress
@@ -87,46 +125,52 @@ func DeployContract(ctx context.Context, contractPath string, deployer string, f
 	if deployer == "" {
 		k, eth, fil, err := NewAccount()
 		if err != nil {
-			return fmt.Errorf("failed to create deployer account: %w", err)
+			return result, fmt.Errorf("failed to create deployer account: %w", err)
 		}
 		key = k
 		ethAddr = eth
 		deployerAddr = fil
-		fmt.Printf("Created deployer account: %s (ETH: %s)\n", deployerAddr, ethAddr)
+		fmt.Fprintf(out, "Created deployer account: %s (ETH: %s)\n", deployerAddr, ethAddr)
 	} else {
 		addr, err := address.NewFromString(deployer)
 		if err != nil {
-			return fmt.Errorf("invalid deployer address: %w", err)
+			return result, fmt.Errorf("invalid deployer address: %w", err)
 		}
 		deployerAddr = addr
 	}
 
+	result.Deployer = deployerAddr.String()
+	if ethAddr != (ethtypes.EthAddress{}) {
+		result.DeployerEth = ethAddr.String()
+	}
+
 	if fundAmount != "" {
 		amount, err := filbig.FromString(fundAmount)
 		if err != nil {
-			return fmt.Errorf("invalid fund amount '%s': %w", fundAmount, err)
+			return result, fmt.Errorf("invalid fund amount '%s': %w", fundAmount, err)
 		}
 		fundAmountAtto := types.BigMul(amount, types.NewInt(1e18))
 
 		_, err = FundWallet(ctx, deployerAddr, fundAmountAtto, true)
 		if err != nil {
-			return fmt.Errorf("failed to fund deployer: %w", err)
+			return result, fmt.Errorf("failed to fund deployer: %w", err)
 		}
-		fmt.Printf("Funded deployer with %s FIL\n", fundAmount)
+		fmt.Fprintf(out, "Funded deployer with %s FIL\n", fundAmount)
 	}
 
-	fmt.Println("Waiting for funds to be available...")
+	fmt.Fprintln(out, "Waiting for funds to be available...")
 	time.Sleep(5 * time.Second)
 
 	contractHex, err := os.ReadFile(contractPath)
 	if err != nil {
-		return fmt.Errorf("failed to read contract file: %w", err)
+		return result, fmt.Errorf("failed to read contract file: %w", err)
 	}
 
 	contract, err := hex.DecodeString(string(contractHex))
 	if err != nil {
-		return fmt.Errorf("failed to decode contract: %w", err)
+		return result, fmt.Errorf("failed to decode contract: %w", err)
 	}
+	contract = append(contract, constructorArgs...)
 
 	api := clientt.GetAPI()
 
@@ -135,26 +179,30 @@ func DeployContract(ctx context.Context, contractPath string, deployer string, f
 		Data: contract,
 	}})
 	if err != nil {
-		return fmt.Errorf("failed to marshal gas params: %w", err)
+		return result, fmt.Errorf("failed to marshal gas params: %w", err)
 	}
 
 	gasLimit, err := api.EthEstimateGas(ctx, gasParams)
 	if err != nil {
-		return fmt.Errorf("failed to estimate gas: %w", err)
+		return result, fmt.Errorf("failed to estimate gas: %w", err)
 	}
 
 	maxPriorityFee, err := api.EthMaxPriorityFeePerGas(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get max priority fee: %w", err)
+		return result, fmt.Errorf("failed to get max priority fee: %w", err)
 	}
 
 	nonce, err := api.MpoolGetNonce(ctx, deployerAddr)
 	if err != nil {
-		return fmt.Errorf("failed to get nonce: %w", err)
+		return result, fmt.Errorf("failed to get nonce: %w", err)
+	}
+	nonce, err = chooseNonce(nonce, nonceOverride, forceNonce)
+	if err != nil {
+		return result, err
 	}
 
 	tx := ethtypes.Eth1559TxArgs{
-		ChainID:              31415926,
+		ChainID:              config.DefaultChainID,
 		Value:                filbig.Zero(),
 		Nonce:                int(nonce),
 		MaxFeePerGas:         types.NanoFil,
@@ -166,55 +214,60 @@ func DeployContract(ctx context.Context, contractPath string, deployer string, f
 		S:                    filbig.Zero(),
 	}
 
-	fmt.Printf("Transaction details:\n")
-	fmt.Printf("  Gas Limit: %d\n", gasLimit)
-	fmt.Printf("  Max Priority Fee: %s\n", maxPriorityFee.String())
-	fmt.Printf("  Nonce: %d\n", nonce)
+	fmt.Fprintf(out, "Transaction details:\n")
+	fmt.Fprintf(out, "  Gas Limit: %d\n", gasLimit)
+	fmt.Fprintf(out, "  Max Priority Fee: %s\n", maxPriorityFee.String())
+	fmt.Fprintf(out, "  Nonce: %d\n", nonce)
 
-	fmt.Println("Signing and submitting transaction...")
+	fmt.Fprintln(out, "Signing and submitting transaction...")
 	if key != nil {
 		if err := SignTransaction(&tx, key.PrivateKey); err != nil {
-			return fmt.Errorf("failed to sign transaction: %w", err)
+			return result, fmt.Errorf("failed to sign transaction: %w", err)
 		}
 	}
 
 	txHash, err := SubmitTransaction(ctx, api, &tx)
 	if err != nil {
-		return fmt.Errorf("failed to submit transaction: %w", err)
+		return result, fmt.Errorf("failed to submit transaction: %w", err)
 	}
+	result.TxHash = txHash.String()
 
-	fmt.Println("Waiting for transaction to be mined...")
-	receipt, err := waitForTransactionReceipt(ctx, api, txHash)
+	fmt.Fprintln(out, "Waiting for transaction to be mined...")
+	receipt, err := waitForTransactionReceipt(ctx, out, api, txHash)
 	if err != nil {
-		return fmt.Errorf("failed to wait for transaction receipt: %w", err)
+		return result, fmt.Errorf("failed to wait for transaction receipt: %w", err)
 	}
 
 	if receipt == nil {
-		return fmt.Errorf("transaction receipt is nil")
+		return result, fmt.Errorf("transaction receipt is nil")
 	}
 
+	result.GasUsed = uint64(receipt.GasUsed)
+
 	if receipt.Status == 1 {
-		fmt.Printf("Contract deployed successfully!\n")
-		fmt.Printf("Contract Address: %s\n", receipt.ContractAddress)
+		fmt.Fprintf(out, "Contract deployed successfully!\n")
+		fmt.Fprintf(out, "Contract Address: %s\n", receipt.ContractAddress)
+		result.Address = config.ChecksumAddress(receipt.ContractAddress.String())
 
-		if err := saveDeploymentArtifacts(contractPath, receipt.ContractAddress.String(), txHash, deployerAddr, ethAddr, key, generateBindings, workspace, contractName, abiPath); err != nil {
-			fmt.Printf("Warning: failed to save deployment artifacts: %v\n", err)
+		if err := saveDeploymentArtifacts(out, contractPath, receipt.ContractAddress.String(), txHash, deployerAddr, ethAddr, key, generateBindings, workspace, contractName, abiPath, bindingsDir, bindingsPkg, result); err != nil {
+			fmt.Fprintf(out, "Warning: failed to save deployment artifacts: %v\n", err)
 		}
 
 	} else {
-		return fmt.Errorf("transaction failed with status: %d", receipt.Status)
+		return result, fmt.Errorf("transaction failed with status: %d", receipt.Status)
 	}
 
-	return nil
+	return result, nil
 }
 
-func saveDeploymentArtifacts(contractPath, contractAddress string, txHash ethtypes.EthHash, deployerAddr address.Address, ethAddr ethtypes.EthAddress, key *key.Key, generateBindings bool, workspace, contractName, abiPath string) error {
+func saveDeploymentArtifacts(out io.Writer, contractPath, contractAddress string, txHash ethtypes.EthHash, deployerAddr address.Address, ethAddr ethtypes.EthAddress, key *key.Key, generateBindings bool, workspace, contractName, abiPath, bindingsDir, bindingsPkg string, result *deployResult) error {
 	manager := NewContractManager(workspace, "")
 
 	if contractName == "" {
 		baseName := filepath.Base(contractPath)
 		contractName = strings.TrimSuffix(baseName, filepath.Ext(baseName))
 	}
+	result.Name = contractName
 
 	contractEthAddr, err := ethtypes.ParseEthAddress(contractAddress)
 	if err != nil {
@@ -243,13 +296,17 @@ func saveDeploymentArtifacts(contractPath, contractAddress string, txHash ethtyp
 	if err := os.MkdirAll(contractsDir, 0755); err != nil {
 		return fmt.Errorf("failed to create contracts directory: %w", err)
 	}
+	if bindingsDir == "" {
+		bindingsDir = contractsDir
+	}
 
 	bytecodePath := filepath.Join(contractsDir, fmt.Sprintf("%s.bin", strings.ToLower(contractName)))
 	if err := os.WriteFile(bytecodePath, contractHex, 0644); err != nil {
 		return fmt.Errorf("failed to save bytecode: %w", err)
 	}
 
-	fmt.Printf("Saved contract bytecode to %s\n", bytecodePath)
+	fmt.Fprintf(out, "Saved contract bytecode to %s\n", bytecodePath)
+	result.BytecodePath = bytecodePath
 
 	finalAbiPath := filepath.Join(contractsDir, fmt.Sprintf("%s.abi.json", strings.ToLower(contractName)))
 
@@ -264,13 +321,13 @@ func saveDeploymentArtifacts(contractPath, contractAddress string, txHash ethtyp
 		for _, path := range possiblePaths {
 			if _, err := os.Stat(path); err == nil {
 				abiPath = path
-				fmt.Printf("Auto-detected ABI file: %s\n", abiPath)
+				fmt.Fprintf(out, "Auto-detected ABI file: %s\n", abiPath)
 				break
 			}
 		}
 
 		if abiPath == "" {
-			fmt.Printf("No pre-compiled ABI found, attempting to generate from source...\n")
+			fmt.Fprintf(out, "No pre-compiled ABI found, attempting to generate from source...\n")
 
 			possibleSources := []string{
 				fmt.Sprintf("contracts/%s.sol", contractName),
@@ -281,7 +338,7 @@ func saveDeploymentArtifacts(contractPath, contractAddress string, txHash ethtyp
 			for _, path := range possibleSources {
 				if _, err := os.Stat(path); err == nil {
 					solPath = path
-					fmt.Printf("Found Solidity source: %s\n", solPath)
+					fmt.Fprintf(out, "Found Solidity source: %s\n", solPath)
 					break
 				}
 			}
@@ -290,12 +347,12 @@ func saveDeploymentArtifacts(contractPath, contractAddress string, txHash ethtyp
 				tempAbiPath := fmt.Sprintf("contracts/%s.abi", contractName)
 				if generatedAbi, err := generateABIFromSolidity(solPath, contractName, tempAbiPath); err == nil {
 					abiPath = generatedAbi
-					fmt.Printf("Generated ABI from Solidity source: %s\n", abiPath)
+					fmt.Fprintf(out, "Generated ABI from Solidity source: %s\n", abiPath)
 				} else {
-					fmt.Printf("Warning: Failed to generate ABI from Solidity: %v\n", err)
+					fmt.Fprintf(out, "Warning: Failed to generate ABI from Solidity: %v\n", err)
 				}
 			} else {
-				fmt.Printf("WARNING: No Solidity source found in contracts/ directory\n")
+				fmt.Fprintf(out, "WARNING: No Solidity source found in contracts/ directory\n")
 			}
 		}
 	}
@@ -315,11 +372,11 @@ func saveDeploymentArtifacts(contractPath, contractAddress string, txHash ethtyp
 			return fmt.Errorf("failed to save ABI: %w", err)
 		}
 
-		fmt.Printf("Saved ABI to %s\n", finalAbiPath)
+		fmt.Fprintf(out, "Saved ABI to %s\n", finalAbiPath)
 	} else {
-		fmt.Printf("WARNING: Could not find or generate ABI\n")
-		fmt.Printf("Creating empty ABI - Go bindings will NOT have contract methods\n")
-		fmt.Printf("To fix: Place Solidity source at contracts/%s.sol\n", contractName)
+		fmt.Fprintf(out, "WARNING: Could not find or generate ABI\n")
+		fmt.Fprintf(out, "Creating empty ABI - Go bindings will NOT have contract methods\n")
+		fmt.Fprintf(out, "To fix: Place Solidity source at contracts/%s.sol\n", contractName)
 
 		minimalABI := []interface{}{}
 		abiBytes, err := json.Marshal(minimalABI)
@@ -331,17 +388,19 @@ func saveDeploymentArtifacts(contractPath, contractAddress string, txHash ethtyp
 			return fmt.Errorf("failed to save minimal ABI: %w", err)
 		}
 
-		fmt.Printf("Saved empty ABI to %s\n", finalAbiPath)
+		fmt.Fprintf(out, "Saved empty ABI to %s\n", finalAbiPath)
 	}
 
 	deployedContract.AbiPath = finalAbiPath
+	result.ABIPath = finalAbiPath
 
 	if generateBindings {
-		if bindingsPath, err := generateGoBindingsFromHex(contractName, finalAbiPath, bytecodePath, contractsDir); err == nil {
+		if bindingsPath, err := generateGoBindingsFromHex(contractName, finalAbiPath, bytecodePath, bindingsDir, bindingsPkg); err == nil {
 			deployedContract.BindingsPath = bindingsPath
-			fmt.Printf("Generated Go bindings to %s\n", bindingsPath)
+			result.BindingsPath = bindingsPath
+			fmt.Fprintf(out, "Generated Go bindings to %s\n", bindingsPath)
 		} else {
-			fmt.Printf("Warning: failed to generate Go bindings: %v\n", err)
+			fmt.Fprintf(out, "Warning: failed to generate Go bindings: %v\n", err)
 		}
 	}
 
@@ -349,25 +408,44 @@ func saveDeploymentArtifacts(contractPath, contractAddress string, txHash ethtyp
 		return fmt.Errorf("failed to save deployment info: %w", err)
 	}
 
-	fmt.Printf("Saved deployment information to workspace/deployments.json\n")
+	fmt.Fprintf(out, "Saved deployment information to workspace/deployments.json\n")
 
 	if err := manager.saveDeployerAccount(deployedContract); err != nil {
-		fmt.Printf("Warning: failed to save deployer account: %v\n", err)
+		fmt.Fprintf(out, "Warning: failed to save deployer account: %v\n", err)
 	}
 
 	return nil
 }
 
-func generateGoBindingsFromHex(contractName, abiPath, bytecodePath, contractsDir string) (string, error) {
-	bindingsPath := filepath.Join(contractsDir, fmt.Sprintf("%s.go", strings.ToLower(contractName)))
+func generateGoBindingsFromHex(contractName, abiPath, bytecodePath, outputDir, pkg string) (string, error) {
+	return runAbigen(contractName, abiPath, bytecodePath, outputDir, pkg)
+}
+
+// DefaultBindingsPackage is the Go package name used for generated bindings
+const DefaultBindingsPackage = "contracts"
+
+// runAbigen writes Go bindings for contractName into outputDir using the given package name.
+// bytecodePath is optional; when set, the bindings include a deploy function.
+func runAbigen(contractName, abiPath, bytecodePath, outputDir, pkg string) (string, error) {
+	if pkg == "" {
+		pkg = DefaultBindingsPackage
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create bindings directory: %w", err)
+	}
+
+	bindingsPath := filepath.Join(outputDir, fmt.Sprintf("%s.go", strings.ToLower(contractName)))
 
-	cmd := exec.Command("abigen",
-		"--abi", abiPath,
-		"--bin", bytecodePath,
-		"--pkg", "contracts",
+	args := []string{"--abi", abiPath}
+	if bytecodePath != "" {
+		args = append(args, "--bin", bytecodePath)
+	}
+	args = append(args,
+		"--pkg", pkg,
 		"--type", contractName,
 		"--out", bindingsPath)
 
+	cmd := exec.Command("abigen", args...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return "", fmt.Errorf("failed to generate Go bindings: %w, output: %s", err, string(output))
@@ -412,28 +490,28 @@ func generateABIFromSolidity(solPath, contractName, outputPath string) (string,
 	return generatedAbi, nil
 }
 
-func compileWithSolc(contractPath string) error {
-	if _, err := exec.LookPath("solc"); err != nil {
-		return fmt.Errorf("solc not found in PATH")
+func compileWithSolc(out io.Writer, contractPath string) error {
+	if err := RequireTools("solc"); err != nil {
+		return err
 	}
 
-	fmt.Printf("Compiling %s with solc...\n", contractPath)
+	fmt.Fprintf(out, "Compiling %s with solc...\n", contractPath)
 
 	cmd := exec.Command("solc", "--bin", "--abi", "--optimize", contractPath, "-o", "contracts/", "--overwrite")
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = out
 	cmd.Stderr = os.Stderr
 
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("solc compilation failed: %w", err)
 	}
 
-	fmt.Println("Compilation successful")
+	fmt.Fprintln(out, "Compilation successful")
 	return nil
 }
 
 func compileWithForge() error {
-	if _, err := exec.LookPath("forge"); err != nil {
-		return fmt.Errorf("forge not found in PATH")
+	if err := RequireTools("forge"); err != nil {
+		return err
 	}
 
 	fmt.Println("Compiling contracts with forge...")
@@ -497,6 +575,15 @@ var ContractCmd = &cli.Command{
 					Name:  "bindings",
 					Usage: "Generate Go bindings using abigen and save to disk",
 				},
+				&cli.StringFlag{
+					Name:  "bindings-dir",
+					Usage: "Directory to write Go bindings to (default: <workspace>/contracts)",
+				},
+				&cli.StringFlag{
+					Name:  "bindings-pkg",
+					Value: DefaultBindingsPackage,
+					Usage: "Go package name for generated bindings",
+				},
 				&cli.BoolFlag{
 					Name:  "compile",
 					Usage: "Compile contract before deployment using solc",
@@ -514,29 +601,32 @@ var ContractCmd = &cli.Command{
 					Name:  "abi",
 					Usage: "Path to ABI file for the contract (optional, will try to extract from source if not provided)",
 				},
+				&cli.StringFlag{
+					Name:  "constructor-args-file",
+					Usage: `JSON file of typed constructor arguments: [{"type": "uint256", "value": "1"}, ...]`,
+				},
+				&cli.Uint64Flag{
+					Name:  "nonce",
+					Usage: "Use this nonce instead of the account's next nonce",
+				},
+				&cli.BoolFlag{
+					Name:  "force",
+					Usage: "Allow --nonce below the account's current nonce (e.g. to replace a transaction)",
+				},
+				&cli.BoolFlag{
+					Name:  "json",
+					Usage: "Print the result as a single JSON object (progress goes to stderr)",
+				},
 			},
 			Action: func(c *cli.Context) error {
-				if c.NArg() != 1 {
-					return fmt.Errorf("expected 1 argument: <contract-file>")
-				}
-
-				ctx := context.Background()
-				contractFile := c.Args().Get(0)
-				deployer := c.String("deployer")
-				fundAmount := c.String("fund")
-				generateBindings := c.Bool("bindings")
-				shouldCompile := c.Bool("compile")
-				workspace := c.String("workspace")
-				contractName := c.String("contract-name")
-				abiPath := c.String("abi")
-
-				if shouldCompile {
-					if err := compileWithSolc(contractFile); err != nil {
-						return fmt.Errorf("compilation failed: %w", err)
-					}
+				if !jsonOutput(c) {
+					_, err := deployFromHex(c, os.Stdout)
+					return err
 				}
 
-				return DeployContract(ctx, contractFile, deployer, fundAmount, generateBindings, workspace, contractName, abiPath)
+				// Progress goes to stderr so stdout carries only the JSON result
+				result, err := deployFromHex(c, os.Stderr)
+				return printDeployResultJSON(os.Stdout, result, err)
 			},
 		},
 		{
@@ -563,7 +653,11 @@ var ContractCmd = &cli.Command{
 				},
 				&cli.StringFlag{
 					Name:  "constructor-args",
-					Usage: "Constructor arguments (comma-separated)",
+					Usage: `Constructor arguments: comma-separated, with "double quotes" around values containing commas, or a JSON array`,
+				},
+				&cli.StringFlag{
+					Name:  "constructor-args-file",
+					Usage: `JSON file of typed constructor arguments: [{"type": "uint256", "value": "1"}, ...]`,
 				},
 				&cli.StringFlag{
 					Name:  "workspace",
@@ -603,6 +697,15 @@ var ContractCmd = &cli.Command{
 					Name:  "bindings",
 					Usage: "Generate Go bindings using abigen and save to disk",
 				},
+				&cli.StringFlag{
+					Name:  "bindings-dir",
+					Usage: "Directory to write Go bindings to (default: <workspace>/contracts)",
+				},
+				&cli.StringFlag{
+					Name:  "bindings-pkg",
+					Value: DefaultBindingsPackage,
+					Usage: "Go package name for generated bindings",
+				},
 			},
 			Action: deployFromGit,
 		},
@@ -648,6 +751,10 @@ var ContractCmd = &cli.Command{
 					return fmt.Errorf("failed to parse config file: %w", err)
 				}
 
+				if err := RequireTools("git"); err != nil {
+					return err
+				}
+
 				manager := NewContractManager(workspace, "")
 
 				for _, cdef := range cfg.Contracts {
@@ -708,6 +815,15 @@ var ContractCmd = &cli.Command{
 					Name:  "bindings",
 					Usage: "Generate Go bindings using abigen and save to disk",
 				},
+				&cli.StringFlag{
+					Name:  "bindings-dir",
+					Usage: "Directory to write Go bindings to (default: <workspace>/contracts)",
+				},
+				&cli.StringFlag{
+					Name:  "bindings-pkg",
+					Value: DefaultBindingsPackage,
+					Usage: "Go package name for generated bindings",
+				},
 				&cli.BoolFlag{
 					Name:  "compile",
 					Usage: "Compile contracts with forge before deployment",
@@ -720,6 +836,47 @@ var ContractCmd = &cli.Command{
 					Name:  "env",
 					Usage: "Override environment variables (format: KEY=VALUE, can be used multiple times)",
 				},
+				&cli.StringSliceFlag{
+					Name:  "only",
+					Usage: "Deploy only these contracts (comma-separated or repeated); unselected dependencies must already be in deployments.json",
+				},
+				&cli.BoolFlag{
+					Name:  "skip-preflight",
+					Usage: "Skip the deployer balance check before deploying",
+				},
+				&cli.BoolFlag{
+					Name:  "topup",
+					Usage: "Top up an underfunded deployer from the node's default wallet instead of failing the preflight",
+				},
+				&cli.Uint64Flag{
+					Name:  "deploy-gas",
+					Value: DefaultDeployGas,
+					Usage: "Gas budget per contract when the preflight cannot estimate a deployment",
+				},
+				&cli.StringFlag{
+					Name:  "network",
+					Usage: "Deploy to a network from the config's \"networks\" section, writing deployments.<network>.json",
+				},
+				&cli.BoolFlag{
+					Name:  "idempotent",
+					Usage: "Skip contracts already in deployments.json whose config is unchanged",
+				},
+				&cli.BoolFlag{
+					Name:  "wait-all",
+					Usage: "Submit each contract's post-deployment actions together and wait for their receipts concurrently, instead of a fixed delay between contracts",
+				},
+				&cli.BoolFlag{
+					Name:  "keep-going",
+					Usage: "Continue with the remaining contracts when one fails instead of stopping at the first failure",
+				},
+				&cli.StringSliceFlag{
+					Name:  "skip",
+					Usage: "Skip these contracts (comma-separated or repeated); skipped dependencies must already be in deployments.json",
+				},
+				&cli.StringFlag{
+					Name:  "continue-from",
+					Usage: "Resume a failed run at this contract, skipping the ones before it in deployment order (their records must be in deployments.json)",
+				},
 			},
 			Action: deployFromLocal,
 		},
@@ -752,6 +909,61 @@ var ContractCmd = &cli.Command{
 			},
 			Action: getDeploymentInfo,
 		},
+		{
+			Name:  "diff",
+			Usage: "Compare the deployments of two workspaces",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "workspace-a",
+					Usage:    "First workspace directory",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "workspace-b",
+					Usage:    "Second workspace directory",
+					Required: true,
+				},
+				&cli.BoolFlag{
+					Name:  "json",
+					Usage: "Output the diff as JSON",
+				},
+			},
+			Action: diffWorkspaces,
+		},
+		{
+			Name:      "events",
+			Usage:     "Tail decoded event logs for a deployed contract using its stored ABI",
+			ArgsUsage: "<contract-name>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "event",
+					Usage: "Event name or signature to filter on, e.g. 'Transfer(address,address,uint256)' (default: all ABI events)",
+				},
+				&cli.Uint64Flag{
+					Name:  "from-block",
+					Usage: "First block to scan (default: current head)",
+				},
+				&cli.Uint64Flag{
+					Name:  "to-block",
+					Usage: "Last block to scan (default: current head)",
+				},
+				&cli.BoolFlag{
+					Name:  "follow",
+					Usage: "Keep tailing new blocks until interrupted",
+				},
+				&cli.DurationFlag{
+					Name:  "interval",
+					Value: 5 * time.Second,
+					Usage: "Polling interval for --follow",
+				},
+				&cli.StringFlag{
+					Name:  "workspace",
+					Usage: "Workspace directory",
+					Value: "./workspace",
+				},
+			},
+			Action: contractEvents,
+		},
 		{
 			Name:  "cleanup",
 			Usage: "Clean up temporary project directories",
@@ -761,9 +973,198 @@ var ContractCmd = &cli.Command{
 					Usage: "Workspace directory",
 					Value: "./workspace",
 				},
+				&cli.BoolFlag{
+					Name:    "yes",
+					Aliases: []string{"y"},
+					Usage:   "Skip the confirmation prompt",
+				},
 			},
 			Action: cleanupWorkspace,
 		},
+		{
+			Name:  "reset",
+			Usage: "Reset a workspace, removing clones and optionally preserving state files",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "workspace",
+					Usage: "Workspace directory",
+					Value: "./workspace",
+				},
+				&cli.BoolFlag{
+					Name:  "keep-deployments",
+					Usage: "Preserve deployments.json",
+				},
+				&cli.BoolFlag{
+					Name:  "keep-accounts",
+					Usage: "Preserve accounts.json, the keystore, and deployer-env.sh",
+				},
+				&cli.BoolFlag{
+					Name:  "keep-artifacts",
+					Usage: "Preserve the contracts directory (ABIs, bytecode, bindings)",
+				},
+				&cli.BoolFlag{
+					Name:    "yes",
+					Aliases: []string{"y"},
+					Usage:   "Skip the confirmation prompt",
+				},
+			},
+			Action: resetWorkspace,
+		},
+		{
+			Name:  "migrate",
+			Usage: "Upgrade a workspace's deployments.json and accounts.json to the current format",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "workspace",
+					Usage: "Workspace directory",
+					Value: "./workspace",
+				},
+				&cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "Show what would change without writing",
+				},
+			},
+			Action: migrateWorkspace,
+		},
+		{
+			Name:  "export",
+			Usage: "Bundle a workspace (clones, artifacts, deployments, accounts) into a tarball",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "workspace",
+					Usage: "Workspace directory",
+					Value: "./workspace",
+				},
+				&cli.StringFlag{
+					Name:  "out",
+					Usage: "Output bundle path",
+					Value: "bundle.tar.gz",
+				},
+			},
+			Action: exportWorkspace,
+		},
+		{
+			Name:  "import-bundle",
+			Usage: "Restore a workspace from a bundle created by export, verifying checksums",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "bundle",
+					Usage:    "Bundle path",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  "workspace",
+					Usage: "Workspace directory to restore into",
+					Value: "./workspace",
+				},
+				&cli.StringFlag{
+					Name:  "sha256",
+					Usage: "Expected SHA-256 of the bundle file (as printed by export)",
+				},
+				&cli.BoolFlag{
+					Name:  "force",
+					Usage: "Restore into a non-empty workspace, overwriting existing files",
+				},
+			},
+			Action: importBundle,
+		},
+		{
+			Name:  "upgrade",
+			Usage: "Deploy a new implementation and upgrade a UUPS proxy to it",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "proxy",
+					Usage:    "Proxy contract name in deployments.json",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "new-impl",
+					Usage:    "New implementation to deploy (format: path/to/Contract.sol:ContractName)",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  "project-dir",
+					Usage: "Cloned project directory containing the implementation (default: <workspace>/<proxy name>)",
+				},
+				&cli.StringFlag{
+					Name:  "init-data",
+					Usage: "Hex-encoded call data passed to upgradeToAndCall (default: none)",
+				},
+				&cli.BoolFlag{
+					Name:  "legacy-upgrade-to",
+					Usage: "Call upgradeTo(address) instead of upgradeToAndCall(address,bytes)",
+				},
+				&cli.StringFlag{
+					Name:  "deployer-key",
+					Usage: "Private key of the proxy owner (default: the proxy's deployer key)",
+				},
+				&cli.StringFlag{
+					Name:  "workspace",
+					Usage: "Workspace directory",
+					Value: "./workspace",
+				},
+				&cli.StringFlag{
+					Name:  "rpc-url",
+					Usage: "RPC URL for deployment",
+					Value: "http://localhost:1234/rpc/v1",
+				},
+			},
+			Action: upgradeProxy,
+		},
+		{
+			Name:  "verify",
+			Usage: "Submit a deployed contract's source for verification to a block explorer",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "name",
+					Usage:    "Contract name in deployments.json",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "explorer-url",
+					Usage:    "Etherscan-compatible explorer API endpoint (e.g. https://filecoin-testnet.blockscout.com/api)",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:    "api-key",
+					Usage:   "Explorer API key",
+					EnvVars: []string{"FILWIZARD_EXPLORER_API_KEY"},
+				},
+				&cli.StringFlag{
+					Name:  "contract",
+					Usage: "Contract identifier (format: path/to/Contract.sol:ContractName, default: from --config)",
+				},
+				&cli.StringFlag{
+					Name:  "config",
+					Usage: "Contracts config used to look up the contract path",
+					Value: "config/contracts.json",
+				},
+				&cli.StringFlag{
+					Name:  "project-dir",
+					Usage: "Cloned project directory (default: <workspace>/<name>)",
+				},
+				&cli.StringFlag{
+					Name:  "constructor-args",
+					Usage: "Hex-encoded constructor args (default: recovered from the deployment transaction)",
+				},
+				&cli.DurationFlag{
+					Name:  "poll-interval",
+					Usage: "How often to check the verification status",
+					Value: 5 * time.Second,
+				},
+				&cli.StringFlag{
+					Name:  "workspace",
+					Usage: "Workspace directory",
+					Value: "./workspace",
+				},
+				&cli.StringFlag{
+					Name:  "rpc-url",
+					Usage: "RPC URL used to fetch the deployment transaction",
+					Value: "http://localhost:1234/rpc/v1",
+				},
+			},
+			Action: verifyContract,
+		},
 		{
 			Name:  "call",
 			Usage: "Universal contract interaction with automatic type detection",
@@ -771,18 +1172,66 @@ var ContractCmd = &cli.Command{
 				{
 					Name:      "read",
 					Usage:     "Call a read-only contract method (view/pure)",
-					ArgsUsage: "<contract-name> <method-name> [args...]",
-					Action:    callReadMethod,
+					ArgsUsage: "<contract-name|address> <method-name> [args...]",
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:  "calls",
+							Usage: "Comma-separated methods to read in one invocation, args separated by ':' (e.g. totalSupply,balanceOf:0x...)",
+						},
+						&cli.StringFlag{
+							Name:  "calls-file",
+							Usage: "JSON file listing calls to read: [{\"method\": \"...\", \"args\": [...]}]",
+						},
+						&cli.BoolFlag{
+							Name:  "strict",
+							Usage: "Fail instead of warning when the target address has no contract code",
+						},
+						&cli.StringFlag{
+							Name:  "decode-as",
+							Usage: "Decode the return data as these types instead of the uint256/hex dump (e.g. '(address,uint256)')",
+						},
+						&cli.BoolFlag{
+							Name:  "watch",
+							Usage: "Re-read the method every --interval until --count samples or Ctrl+C",
+						},
+						&cli.DurationFlag{
+							Name:  "interval",
+							Usage: "Polling interval for --watch",
+							Value: 2 * time.Second,
+						},
+						&cli.IntFlag{
+							Name:  "count",
+							Usage: "Number of samples for --watch (0 = until interrupted)",
+						},
+						&cli.BoolFlag{
+							Name:  "changes-only",
+							Usage: "With --watch, only print samples whose return data changed",
+						},
+						&cli.BoolFlag{
+							Name:  "json",
+							Usage: "Print the decoded outputs as a JSON object keyed by ABI output name",
+						},
+						&cli.StringFlag{
+							Name:  "block",
+							Value: "latest",
+							Usage: "Block to read at: latest, pending, finalized, safe, earliest, or a block number",
+						},
+					},
+					Action: callReadMethod,
 				},
 				{
 					Name:      "write",
 					Usage:     "Send a transaction to a contract method",
-					ArgsUsage: "<contract-name> <method-name> [args...]",
+					ArgsUsage: "<contract-name|address> <method-name> [args...]",
 					Flags: []cli.Flag{
 						&cli.StringFlag{
 							Name:  "from",
 							Usage: "Account role to send transaction from (creates new if doesn't exist)",
 						},
+						&cli.StringFlag{
+							Name:  "from-key",
+							Usage: "Hex private key to sign with directly, instead of an accounts.json role",
+						},
 						&cli.Uint64Flag{
 							Name:  "gas",
 							Value: 0,
@@ -793,26 +1242,220 @@ var ContractCmd = &cli.Command{
 							Value: "1",
 							Usage: "Amount to fund new accounts (FIL)",
 						},
-					},
-					Action: callWriteMethod,
-				},
-			},
-		},
-	},
-}
-
-func deployFromLocal(c *cli.Context) error {
-	configPath := c.String("config")
-	workspace := c.String("workspace")
-	rpcURL := c.String("rpc-url")
-	defaultGenerateBindings := c.Bool("bindings")
+						&cli.StringFlag{
+							Name:  "gas-price",
+							Usage: "Legacy gas price in attoFIL (overrides the node's suggestion)",
+						},
+						&cli.StringFlag{
+							Name:  "max-fee",
+							Usage: "EIP-1559 max fee per gas in attoFIL",
+						},
+						&cli.StringFlag{
+							Name:  "priority-fee",
+							Usage: "EIP-1559 max priority fee per gas in attoFIL",
+						},
+						&cli.BoolFlag{
+							Name:  "strict",
+							Usage: "Fail instead of warning when the target address has no contract code",
+						},
+						&cli.BoolFlag{
+							Name:  "simulate",
+							Usage: "Run the call with eth_call and report success or the revert reason without sending",
+						},
+						&cli.BoolFlag{
+							Name:  "send",
+							Usage: "With --simulate, send the transaction if the simulation succeeds",
+						},
+						&cli.Uint64Flag{
+							Name:  "nonce",
+							Usage: "Use this nonce instead of the sender's next nonce",
+						},
+						&cli.BoolFlag{
+							Name:  "force",
+							Usage: "Allow --nonce below the sender's current nonce (e.g. to replace a transaction)",
+						},
+					},
+					Action: callWriteMethod,
+				},
+				{
+					Name:      "profile",
+					Usage:     "Send a write method repeatedly and report gas-used statistics",
+					ArgsUsage: "<contract-name|address> <method-name> [args...]",
+					Flags: []cli.Flag{
+						&cli.IntFlag{
+							Name:  "count",
+							Value: 10,
+							Usage: "Number of transactions to send",
+						},
+						&cli.StringFlag{
+							Name:  "from",
+							Usage: "Account role to send transactions from (creates new if doesn't exist)",
+						},
+						&cli.StringFlag{
+							Name:  "fund",
+							Value: "1",
+							Usage: "Amount to fund new accounts (FIL)",
+						},
+						&cli.Uint64Flag{
+							Name:  "gas",
+							Usage: "Gas limit per transaction (0 = auto-estimate)",
+						},
+						&cli.StringFlag{
+							Name:  "gas-price",
+							Usage: "Legacy gas price in attoFIL (overrides the node's suggestion)",
+						},
+						&cli.StringFlag{
+							Name:  "max-fee",
+							Usage: "EIP-1559 max fee per gas in attoFIL",
+						},
+						&cli.StringFlag{
+							Name:  "priority-fee",
+							Usage: "EIP-1559 max priority fee per gas in attoFIL",
+						},
+						&cli.StringFlag{
+							Name:  "workspace",
+							Usage: "Workspace directory",
+							Value: "./workspace",
+						},
+					},
+					Action: profileWriteMethod,
+				},
+			},
+		},
+		{
+			Name:  "send-raw",
+			Usage: "Send a transaction with pre-built calldata, bypassing ABI encoding",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "to",
+					Usage:    "Target contract name or 0x address",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "data",
+					Usage:    "Hex-encoded calldata (0x...)",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  "value",
+					Usage: "FIL to send with the transaction",
+					Value: "0",
+				},
+				&cli.StringFlag{
+					Name:  "from",
+					Usage: "Account role to send transaction from (creates new if doesn't exist)",
+				},
+				&cli.StringFlag{
+					Name:  "fund",
+					Value: "1",
+					Usage: "Amount to fund new accounts (FIL)",
+				},
+				&cli.Uint64Flag{
+					Name:  "gas",
+					Usage: "Gas limit (0 = auto-estimate)",
+				},
+				&cli.StringFlag{
+					Name:  "gas-price",
+					Usage: "Legacy gas price in attoFIL (overrides the node's suggestion)",
+				},
+				&cli.StringFlag{
+					Name:  "max-fee",
+					Usage: "EIP-1559 max fee per gas in attoFIL",
+				},
+				&cli.StringFlag{
+					Name:  "priority-fee",
+					Usage: "EIP-1559 max priority fee per gas in attoFIL",
+				},
+				&cli.StringFlag{
+					Name:  "workspace",
+					Usage: "Workspace directory",
+					Value: "./workspace",
+				},
+			},
+			Action: sendRawCallData,
+		},
+		workspaceCmd,
+	},
+}
+
+// deployFromHex runs `contract deploy` and returns what was deployed
+func deployFromHex(c *cli.Context, out io.Writer) (*deployResult, error) {
+	if c.NArg() != 1 {
+		return nil, fmt.Errorf("expected 1 argument: <contract-file>")
+	}
+
+	ctx := c.Context
+	contractFile := c.Args().Get(0)
+	deployer := c.String("deployer")
+	fundAmount := c.String("fund")
+	generateBindings := c.Bool("bindings")
 	shouldCompile := c.Bool("compile")
+	workspace := c.String("workspace")
+	contractName := c.String("contract-name")
+	abiPath := c.String("abi")
+
+	var tools []string
 	if shouldCompile {
-		if err := compileWithForge(); err != nil {
-			return fmt.Errorf("compilation failed: %w", err)
+		tools = append(tools, "solc")
+	}
+	if generateBindings {
+		tools = append(tools, "abigen")
+	}
+	if err := RequireTools(tools...); err != nil {
+		return nil, err
+	}
+
+	if shouldCompile {
+		if err := compileWithSolc(out, contractFile); err != nil {
+			return nil, fmt.Errorf("compilation failed: %w", err)
+		}
+	}
+
+	var constructorArgs []byte
+	if argsFile := c.String("constructor-args-file"); argsFile != "" {
+		args, err := config.LoadConstructorArgs(argsFile)
+		if err != nil {
+			return nil, err
+		}
+		constructorArgs, err = config.EncodeConstructorArgs(args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode constructor args: %w", err)
 		}
 	}
 
+	var nonce *uint64
+	if c.IsSet("nonce") {
+		n := c.Uint64("nonce")
+		nonce = &n
+	}
+
+	return DeployContract(ctx, out, contractFile, deployer, fundAmount, generateBindings, workspace, contractName, abiPath, c.String("bindings-dir"), c.String("bindings-pkg"), constructorArgs, nonce, c.Bool("force"))
+}
+
+// printDeployResultJSON writes result to w as a single JSON object, recording err in its error field
+func printDeployResultJSON(w io.Writer, result *deployResult, err error) error {
+	if result == nil {
+		result = &deployResult{}
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	data, marshalErr := json.MarshalIndent(result, "", "  ")
+	if marshalErr != nil {
+		return fmt.Errorf("failed to marshal deploy result: %w", marshalErr)
+	}
+	fmt.Fprintln(w, string(data))
+	return err
+}
+
+func deployFromLocal(c *cli.Context) error {
+	configPath := c.String("config")
+	workspace := c.String("workspace")
+	rpcURL := c.String("rpc-url")
+	defaultGenerateBindings := c.Bool("bindings")
+	shouldCompile := c.Bool("compile")
+
 	// Capture initial environment for overriding config later
 	initialEnv := make(map[string]string)
 	for _, e := range os.Environ() {
@@ -829,6 +1472,22 @@ func deployFromLocal(c *cli.Context) error {
 		return fmt.Errorf("failed to load contracts config: %w", err)
 	}
 
+	network := c.String("network")
+	var networkConfig *config.NetworkConfig
+	if network != "" {
+		networkConfig, err = contractsConfig.Network(network)
+		if err != nil {
+			return err
+		}
+		if !c.IsSet("rpc-url") {
+			rpcURL = networkConfig.RPC
+		}
+		if err := verifyChainID(c.Context, rpcURL, networkConfig.ChainID); err != nil {
+			return fmt.Errorf("network %s: %w", network, err)
+		}
+		fmt.Printf("Deploying to network %s (%s)\n", network, rpcURL)
+	}
+
 	// Initialize Environment map if nil
 	if contractsConfig.Environment == nil {
 		contractsConfig.Environment = make(map[string]string)
@@ -868,7 +1527,7 @@ func deployFromLocal(c *cli.Context) error {
 		fmt.Printf("  Setting FILECOIN_RPC=%s (from --rpc-url flag)\n", rpcURL)
 	}
 
-	deploymentsPath := filepath.Join(workspace, "deployments.json")
+	deploymentsPath := filepath.Join(workspace, config.NetworkFileName("deployments.json", network))
 	deployments, err := config.LoadDeploymentRecords(deploymentsPath)
 	if err != nil {
 		return fmt.Errorf("failed to load deployment records: %w", err)
@@ -877,6 +1536,7 @@ func deployFromLocal(c *cli.Context) error {
 	// If user supplied an import-output file, import addresses into deployments.json
 	if importOutput != "" {
 		managerForImport := NewContractManager(workspace, rpcURL)
+		managerForImport.SetDeploymentsFile(deploymentsPath)
 		fmt.Printf("Importing script output from %s into %s...\n", importOutput, deploymentsPath)
 		if err := managerForImport.ImportScriptOutputToDeployments(configPath, deploymentsPath, importOutput, "", ""); err != nil {
 			return fmt.Errorf("failed to import script output: %w", err)
@@ -894,6 +1554,32 @@ func deployFromLocal(c *cli.Context) error {
 		return fmt.Errorf("failed to determine deployment order: %w", err)
 	}
 
+	orderedContracts, err = config.FilterDeploymentOrder(orderedContracts, c.StringSlice("only"), c.StringSlice("skip"), deployments, contractsConfig.AddressBook)
+	if err != nil {
+		return fmt.Errorf("invalid contract selection: %w", err)
+	}
+
+	if continueFrom := c.String("continue-from"); continueFrom != "" {
+		remaining, err := config.ContinueFrom(orderedContracts, continueFrom, deployments, contractsConfig.AddressBook)
+		if err != nil {
+			return fmt.Errorf("invalid --continue-from: %w", err)
+		}
+		for _, skipped := range orderedContracts[:len(orderedContracts)-len(remaining)] {
+			fmt.Printf("Skipping %s: before %s in deployment order\n", skipped.Name, continueFrom)
+		}
+		orderedContracts = remaining
+	}
+
+	if err := RequireTools(deployLocalTools(orderedContracts, defaultGenerateBindings, shouldCompile)...); err != nil {
+		return err
+	}
+
+	if shouldCompile {
+		if err := compileWithForge(); err != nil {
+			return fmt.Errorf("compilation failed: %w", err)
+		}
+	}
+
 	fmt.Printf("Deployment order: ")
 	for i, contract := range orderedContracts {
 		if i > 0 {
@@ -904,10 +1590,15 @@ func deployFromLocal(c *cli.Context) error {
 	fmt.Println()
 
 	manager := NewContractManager(workspace, rpcURL)
+	manager.SetBindingsOutput(c.String("bindings-dir"), c.String("bindings-pkg"))
+	manager.SetDeploymentsFile(deploymentsPath)
 
-	// Try to load existing deployer account from accounts.json
+	// Prefer the network's deployer key, then the deployer account from accounts.json
 	var deployerKey string
-	if accounts, err := loadAccounts(workspace); err == nil {
+	if networkConfig != nil && networkConfig.DeployerKey != "" {
+		deployerKey = networkConfig.DeployerKey
+		fmt.Printf("Using deployer key from network %s\n", network)
+	} else if accounts, err := loadAccounts(workspace); err == nil {
 		if deployerAccount, exists := accounts.Accounts["deployer"]; exists {
 			deployerKey = deployerAccount.PrivateKey
 			fmt.Printf("Using existing deployer account: %s\n", deployerAccount.EthAddress)
@@ -918,7 +1609,7 @@ func deployFromLocal(c *cli.Context) error {
 		manager.SetDeployerKey(deployerKey)
 	} else {
 		fmt.Println("Creating new deployer account...")
-		privateKey, address, err := manager.CreateDeployerAccount()
+		privateKey, address, err := manager.CreateDeployerAccount(c.Context)
 		if err != nil {
 			return fmt.Errorf("failed to create deployer account: %w", err)
 		}
@@ -929,24 +1620,97 @@ func deployFromLocal(c *cli.Context) error {
 	// Set PRIVATE_KEY environment variable for deployment scripts
 	os.Setenv("PRIVATE_KEY", manager.GetDeployerKey())
 
+	globalDeployerKey := manager.GetDeployerKey()
+	deployerKeys, err := resolveContractDeployers(workspace, orderedContracts, globalDeployerKey)
+	if err != nil {
+		return err
+	}
+
+	idempotent := c.Bool("idempotent")
+	waitAll := c.Bool("wait-all")
+	outcome := &deployOutcome{keepGoing: c.Bool("keep-going")}
+	var gasUsage []contractGas
+
+	// fail records a contract failure. Without --keep-going it reports what was deployed so far,
+	// including its gas cost, and returns the error that aborts the run.
+	fail := func(name string, err error) error {
+		ferr := outcome.fail(name, err)
+		if ferr != nil {
+			outcome.printSummary()
+			printGasSummary(c.Context, rpcURL, gasUsage)
+		}
+		return ferr
+	}
+
+	if !c.Bool("skip-preflight") {
+		var planned []config.ContractConfig
+		for _, cdef := range orderedContracts {
+			if idempotent {
+				if existing := config.FindLatestDeployment(deployments, cdef.Name); existing != nil &&
+					(existing.ConfigHash == "" || existing.ConfigHash == cdef.Fingerprint()) {
+					continue
+				}
+			}
+			planned = append(planned, cdef)
+		}
+
+		// Each deployer must afford the contracts it deploys
+		var keyOrder []string
+		plannedByKey := make(map[string][]config.ContractConfig)
+		for _, cdef := range planned {
+			key := deployerKeys[cdef.Name]
+			if _, seen := plannedByKey[key]; !seen {
+				keyOrder = append(keyOrder, key)
+			}
+			plannedByKey[key] = append(plannedByKey[key], cdef)
+		}
+		for _, key := range keyOrder {
+			if err := preflightDeployer(c.Context, rpcURL, key, workspace, plannedByKey[key], c.Uint64("deploy-gas"), c.Bool("topup")); err != nil {
+				return fmt.Errorf("preflight failed: %w", err)
+			}
+		}
+	}
+
 	for _, cdef := range orderedContracts {
+		if idempotent {
+			if existing := config.FindLatestDeployment(deployments, cdef.Name); existing != nil {
+				if existing.ConfigHash == "" || existing.ConfigHash == cdef.Fingerprint() {
+					fmt.Printf("Skipping %s: already deployed at %s\n", cdef.Name, existing.Address)
+					outcome.skipped = append(outcome.skipped, cdef.Name)
+					// Keep exports available to contracts that depend on this one
+					contractsConfig.UpdateEnvironmentWithDeployments(cdef.Name, deployments)
+					continue
+				}
+				fmt.Printf("Config for %s changed since it was deployed at %s, redeploying\n", cdef.Name, existing.Address)
+			}
+		}
+
 		name := strings.ToLower(cdef.Name)
 		name = strings.ReplaceAll(name, " ", "-")
 		localCloneDir := filepath.Join(workspace, name)
 
 		absLocalCloneDir, err := filepath.Abs(localCloneDir)
 		if err != nil {
-			fmt.Printf("Warning: failed to get absolute path for %s: %v, skipping %s\n", localCloneDir, err, cdef.Name)
+			if ferr := fail(cdef.Name, fmt.Errorf("failed to get absolute path for %s: %w", localCloneDir, err)); ferr != nil {
+				return ferr
+			}
 			continue
 		}
 
 		if _, err := os.Stat(absLocalCloneDir); os.IsNotExist(err) {
-			fmt.Printf("Warning: local clone directory %s does not exist, skipping %s\n", absLocalCloneDir, cdef.Name)
+			if ferr := fail(cdef.Name, fmt.Errorf("local clone directory %s does not exist (run clone-config first)", absLocalCloneDir)); ferr != nil {
+				return ferr
+			}
 			continue
 		}
 
 		fmt.Printf("====== Deploying %s from local clone ======\n", cdef.Name)
 
+		manager.SetDeployerKey(deployerKeys[cdef.Name])
+		if cdef.Deployer != "" {
+			fmt.Printf("Using deployer role %s for %s\n", cdef.Deployer, cdef.Name)
+		}
+
 		// Set and resolve environment variables for this contract deployment
 		fmt.Printf("Setting environment variables for %s...\n", cdef.Name)
 
@@ -976,11 +1740,10 @@ func deployFromLocal(c *cli.Context) error {
 		for k, v := range envVars {
 			if strings.Contains(v, "{address:") {
 				resolved := contractsConfig.ResolveAddressPlaceholdersWithDeployments(v, deployments)
-				// If still unresolved, fall back to reading workspace/deployments.json which may contain
+				// If still unresolved, fall back to reading the deployments file which may contain
 				// the manager's DeployedContract format
 				if strings.Contains(resolved, "{address:") {
-					depsFile := filepath.Join(workspace, "deployments.json")
-					if data, err := os.ReadFile(depsFile); err == nil {
+					if data, err := os.ReadFile(deploymentsPath); err == nil {
 						var mgrDeps []*DeployedContract
 						if err := json.Unmarshal(data, &mgrDeps); err == nil {
 							// try to find each placeholder and replace
@@ -1043,12 +1806,19 @@ func deployFromLocal(c *cli.Context) error {
 
 		deployments, err = config.LoadDeploymentRecords(deploymentsPath)
 		if err != nil {
-			return fmt.Errorf("failed to reload deployment records before resolving dependencies for %s: %w", cdef.Name, err)
+			if ferr := fail(cdef.Name, fmt.Errorf("failed to reload deployment records before resolving dependencies: %w", err)); ferr != nil {
+				return ferr
+			}
+			continue
 		}
 
-		resolvedArgs, err := config.ResolveDependencies(cdef, deployments)
+		// A dependency that failed earlier in a --keep-going run fails its dependents here
+		resolvedArgs, err := config.ResolveDependencies(cdef, deployments, contractsConfig.AddressBook)
 		if err != nil {
-			return fmt.Errorf("failed to resolve dependencies for %s: %w", cdef.Name, err)
+			if ferr := fail(cdef.Name, fmt.Errorf("failed to resolve dependencies: %w", err)); ferr != nil {
+				return ferr
+			}
+			continue
 		}
 
 		if len(resolvedArgs) > 0 {
@@ -1083,11 +1853,11 @@ func deployFromLocal(c *cli.Context) error {
 			time.Sleep(10 * time.Second)
 
 			fmt.Printf("Running custom deployment script: %s\n", cdef.DeployScript)
-			var err error
-			scriptOutput, err = manager.RunCustomDeployScript(project, cdef.DeployScript)
-			scriptFailed := err != nil
+			var scriptErr error
+			scriptOutput, scriptErr = manager.RunCustomDeployScript(project, cdef.DeployScript)
+			scriptFailed := scriptErr != nil
 			if scriptFailed {
-				fmt.Printf("Warning: deployment script for %s exited with error: %v\n", cdef.Name, err)
+				fmt.Printf("Warning: deployment script for %s exited with error: %v\n", cdef.Name, scriptErr)
 				fmt.Printf("Attempting to import any contract addresses that were successfully deployed...\n")
 			} else {
 				fmt.Printf("Custom deployment script completed successfully\n")
@@ -1100,18 +1870,12 @@ func deployFromLocal(c *cli.Context) error {
 				tempFile, err := os.CreateTemp("", "script_output_*.txt")
 				if err != nil {
 					fmt.Printf("Error: failed to create temp file for script output: %v\n", err)
-					if scriptFailed {
-						continue
-					}
 				} else {
 					defer os.Remove(tempFile.Name())
 					defer tempFile.Close()
 
 					if _, err := tempFile.WriteString(scriptOutput); err != nil {
 						fmt.Printf("Error: failed to write script output to temp file: %v\n", err)
-						if scriptFailed {
-							continue
-						}
 					} else {
 						tempFile.Close()
 
@@ -1119,9 +1883,6 @@ func deployFromLocal(c *cli.Context) error {
 						fmt.Printf("Importing contract addresses from script output...\n")
 						if err := manager.ImportScriptOutputToDeployments(configPath, deploymentsPath, tempFile.Name(), cdef.Name, cdef.MainContract); err != nil {
 							fmt.Printf("Error: failed to import script output: %v\n", err)
-							if scriptFailed {
-								continue
-							}
 						} else {
 							fmt.Printf("Successfully imported contract addresses\n")
 						}
@@ -1130,13 +1891,18 @@ func deployFromLocal(c *cli.Context) error {
 			}
 
 			if scriptFailed {
+				if ferr := fail(cdef.Name, fmt.Errorf("deployment script failed: %w", scriptErr)); ferr != nil {
+					return ferr
+				}
 				continue
 			}
 
 			// Reload deployments to get the imported contract
 			deploymentsFromManager, err := manager.LoadDeployments()
 			if err != nil {
-				fmt.Printf("Error: failed to reload deployments after script import: %v\n", err)
+				if ferr := fail(cdef.Name, fmt.Errorf("failed to reload deployments after script import: %w", err)); ferr != nil {
+					return ferr
+				}
 				continue
 			}
 
@@ -1148,11 +1914,10 @@ func deployFromLocal(c *cli.Context) error {
 				}
 			}
 			if deployedContract == nil {
-				fmt.Printf("Warning: contract %s not found in deployments after script execution\n", cdef.Name)
-				// Create a dummy deployed contract for post-deployment steps
-				deployedContract = &DeployedContract{
-					Name: cdef.Name,
+				if ferr := fail(cdef.Name, fmt.Errorf("deployment script finished but %s was not found in deployments (check the script output or --import-output)", cdef.Name)); ferr != nil {
+					return ferr
 				}
+				continue
 			}
 		} else {
 			contractPath := fmt.Sprintf("%s:%s", project.ContractPath, project.MainContract)
@@ -1163,21 +1928,31 @@ func deployFromLocal(c *cli.Context) error {
 			deployedContract, err = manager.DeployContract(project, contractPath, resolvedArgs, contractGenerateBindings, false)
 
 			if err != nil {
-				fmt.Printf("Error: failed to deploy contract %s: %v\n", cdef.Name, err)
+				if ferr := fail(cdef.Name, err); ferr != nil {
+					return ferr
+				}
 				continue
 			}
 
 			deployments, err = config.LoadDeploymentRecords(deploymentsPath)
 			if err != nil {
-				return fmt.Errorf("failed to reload deployment records: %w", err)
+				if ferr := fail(cdef.Name, fmt.Errorf("failed to reload deployment records: %w", err)); ferr != nil {
+					return ferr
+				}
+				continue
 			}
 		}
 
+		usage := contractGas{Name: cdef.Name}
+		if deployedContract.TransactionHash != (ethtypes.EthHash{}) {
+			usage.Hashes = append(usage.Hashes, common.Hash(deployedContract.TransactionHash))
+		}
+
 		fmt.Printf("\nContract %s deployed successfully!\n", cdef.Name)
 		fmt.Printf("Contract: %s\n", deployedContract.Name)
-		fmt.Printf("Address: %s\n", deployedContract.Address.String())
+		fmt.Printf("Address: %s\n", config.ChecksumAddress(deployedContract.Address.String()))
 		fmt.Printf("Transaction: %s\n", deployedContract.TransactionHash.String())
-		fmt.Printf("Deployer: %s\n", deployedContract.DeployerAddress.String())
+		fmt.Printf("Deployer: %s\n", config.ChecksumAddress(deployedContract.DeployerAddress.String()))
 		if deployedContract.AbiPath != "" {
 			fmt.Printf("ABI Path: %s\n", deployedContract.AbiPath)
 		}
@@ -1185,6 +1960,10 @@ func deployFromLocal(c *cli.Context) error {
 			fmt.Printf("Go Bindings: %s\n", deployedContract.BindingsPath)
 		}
 
+		if err := config.StampConfigHash(deploymentsPath, cdef.Name, cdef.Fingerprint()); err != nil {
+			fmt.Printf("Warning: failed to record config hash for %s: %v\n", cdef.Name, err)
+		}
+
 		// Update environment variables with newly deployed contract addresses
 		deployments, err = config.LoadDeploymentRecords(deploymentsPath)
 		if err != nil {
@@ -1196,20 +1975,128 @@ func deployFromLocal(c *cli.Context) error {
 
 		fmt.Printf("====== Finished %s ======\n\n", cdef.Name)
 
-		if err := config.ExecutePostDeployment(cdef, deployedContract.Address.String(), convertToDeploymentRecords(deployments), rpcURL, manager.GetDeployerKey()); err != nil {
-			fmt.Printf("Warning: Post-deployment actions failed for %s: %v\n", cdef.Name, err)
+		executePostDeployment := config.ExecutePostDeployment
+		if waitAll {
+			executePostDeployment = config.ExecutePostDeploymentBatch
+		}
+		actionResults, postErr := executePostDeployment(c.Context, cdef, deployedContract.Address.String(), convertToDeploymentRecords(deployments), contractsConfig.AddressBook, rpcURL, cfg.Token, manager.GetDeployerKey())
+		if len(actionResults) > 0 {
+			for _, r := range actionResults {
+				if r.TxHash != "" {
+					fmt.Printf("  %s -> %s.%s: %s\n", r.Label, r.Target, r.Method, r.TxHash)
+					usage.Hashes = append(usage.Hashes, common.HexToHash(r.TxHash))
+				}
+			}
+			resultsPath := filepath.Join(workspace, config.NetworkFileName("post-deployment.json", network))
+			if err := config.SavePostDeploymentResults(resultsPath, cdef.Name, actionResults); err != nil {
+				fmt.Printf("Warning: failed to record post-deployment results: %v\n", err)
+			}
+		}
+		gasUsage = append(gasUsage, usage)
+		if postErr != nil {
+			if ferr := fail(cdef.Name, fmt.Errorf("post-deployment actions failed: %w", postErr)); ferr != nil {
+				return ferr
+			}
+		} else {
+			outcome.succeeded = append(outcome.succeeded, cdef.Name)
+		}
+
+		// With --wait-all every receipt has already been awaited
+		if !waitAll {
+			// Wait longer for transaction to be mined and nonce to update
+			fmt.Printf("Waiting for transaction confirmation...\n")
+			time.Sleep(20 * time.Second)
 		}
+	}
 
-		// Wait longer for transaction to be mined and nonce to update
-		fmt.Printf("Waiting for transaction confirmation...\n")
-		time.Sleep(20 * time.Second)
+	outcome.printSummary()
+	printGasSummary(c.Context, rpcURL, gasUsage)
+	if len(outcome.failures) > 0 {
+		return fmt.Errorf("%d of %d contract(s) failed to deploy", len(outcome.failures), len(orderedContracts))
 	}
 
 	fmt.Println("All deployments completed. Check deployments with: ./mpool-tx contract list")
 	return nil
 }
 
+// resolveContractDeployers maps each contract to the key that deploys it: the key of its
+// configured deployer role in accounts.json, or the global deployer key
+func resolveContractDeployers(workspace string, contracts []config.ContractConfig, globalKey string) (map[string]string, error) {
+	keys := make(map[string]string, len(contracts))
+
+	var accounts *AccountsFile
+	for _, cdef := range contracts {
+		if cdef.Deployer == "" {
+			keys[cdef.Name] = globalKey
+			continue
+		}
+
+		if accounts == nil {
+			var err error
+			accounts, err = loadAccounts(workspace)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load accounts for deployer of %s: %w", cdef.Name, err)
+			}
+		}
+		account, ok := accounts.Accounts[cdef.Deployer]
+		if !ok {
+			return nil, fmt.Errorf("deployer role %q for %s not found in accounts.json", cdef.Deployer, cdef.Name)
+		}
+		keys[cdef.Name] = account.PrivateKey
+	}
+
+	return keys, nil
+}
+
+// deployFailure records why a contract failed during deploy-local
+type deployFailure struct {
+	Name string
+	Err  error
+}
+
+// deployOutcome tracks which contracts a deploy-local run deployed, skipped, and failed
+type deployOutcome struct {
+	keepGoing bool
+	succeeded []string
+	skipped   []string
+	failures  []deployFailure
+}
+
+// fail records a contract failure and returns the error that aborts the run, or nil when
+// --keep-going lets the run continue with the next contract
+func (o *deployOutcome) fail(name string, err error) error {
+	o.failures = append(o.failures, deployFailure{Name: name, Err: err})
+	if o.keepGoing {
+		fmt.Printf("Error: %s failed: %v (continuing, --keep-going)\n", name, err)
+		return nil
+	}
+	return fmt.Errorf("deployment of %s failed: %w", name, err)
+}
+
+// printSummary prints which contracts were deployed, skipped, and failed
+func (o *deployOutcome) printSummary() {
+	fmt.Println("====== Deployment summary ======")
+	if len(o.succeeded) > 0 {
+		fmt.Printf("Succeeded (%d): %s\n", len(o.succeeded), strings.Join(o.succeeded, ", "))
+	}
+	if len(o.skipped) > 0 {
+		fmt.Printf("Skipped %d already-deployed contract(s): %s\n", len(o.skipped), strings.Join(o.skipped, ", "))
+	}
+	if len(o.failures) > 0 {
+		fmt.Printf("Failed (%d):\n", len(o.failures))
+		for _, f := range o.failures {
+			fmt.Printf("  %s: %v\n", f.Name, f.Err)
+		}
+	}
+}
+
 func deployFromGit(c *cli.Context) error {
+	if c.String("deploy-script") != "" || c.String("commands") != "" {
+		if err := RequireTools("git"); err != nil {
+			return err
+		}
+	}
+
 	if deployScript := c.String("deploy-script"); deployScript != "" {
 		return deployWithCustomScript(c)
 	}
@@ -1222,10 +2109,15 @@ func deployFromGit(c *cli.Context) error {
 		return fmt.Errorf("main-contract is required for deployment")
 	}
 
+	if err := RequireTools(gitDeployTools(ProjectType(c.String("project-type")), c.Bool("bindings"))...); err != nil {
+		return err
+	}
+
 	manager := NewContractManager(c.String("workspace"), c.String("rpc-url"))
+	manager.SetBindingsOutput(c.String("bindings-dir"), c.String("bindings-pkg"))
 	if c.Bool("create-deployer") {
 		fmt.Println("Creating new deployer account...")
-		privateKey, address, err := manager.CreateDeployerAccount()
+		privateKey, address, err := manager.CreateDeployerAccount(c.Context)
 		if err != nil {
 			return fmt.Errorf("failed to create deployer account: %w", err)
 		}
@@ -1275,11 +2167,25 @@ func deployFromGit(c *cli.Context) error {
 		fmt.Printf("Foundry project - deploying directly with forge create...\n")
 	}
 
+	if c.IsSet("constructor-args") && c.IsSet("constructor-args-file") {
+		return fmt.Errorf("--constructor-args and --constructor-args-file are mutually exclusive")
+	}
+
 	var constructorArgs []string
-	if argsStr := c.String("constructor-args"); argsStr != "" {
-		constructorArgs = strings.Split(argsStr, ",")
-		for i, arg := range constructorArgs {
-			constructorArgs[i] = strings.TrimSpace(arg)
+	var err error
+	if argsFile := c.String("constructor-args-file"); argsFile != "" {
+		args, err := config.LoadConstructorArgs(argsFile)
+		if err != nil {
+			return err
+		}
+		constructorArgs, err = config.ForgeConstructorArgs(args)
+		if err != nil {
+			return fmt.Errorf("invalid --constructor-args-file: %w", err)
+		}
+	} else {
+		constructorArgs, err = splitConstructorArgs(c.String("constructor-args"))
+		if err != nil {
+			return fmt.Errorf("invalid --constructor-args: %w", err)
 		}
 	}
 
@@ -1307,9 +2213,9 @@ func deployFromGit(c *cli.Context) error {
 
 	fmt.Printf("\nContract deployed successfully!\n")
 	fmt.Printf("Contract: %s\n", deployedContract.Name)
-	fmt.Printf("Address: %s\n", deployedContract.Address.String())
+	fmt.Printf("Address: %s\n", config.ChecksumAddress(deployedContract.Address.String()))
 	fmt.Printf("Transaction: %s\n", deployedContract.TransactionHash.String())
-	fmt.Printf("Deployer: %s\n", deployedContract.DeployerAddress.String())
+	fmt.Printf("Deployer: %s\n", config.ChecksumAddress(deployedContract.DeployerAddress.String()))
 	fmt.Printf("Deployer Key: %s\n", deployedContract.DeployerPrivateKey)
 
 	return nil
@@ -1323,6 +2229,13 @@ func listDeployments(c *cli.Context) error {
 		return fmt.Errorf("failed to load deployments: %w", err)
 	}
 
+	if jsonOutput(c) {
+		if deployments == nil {
+			deployments = []*DeployedContract{}
+		}
+		return printJSON(deployments)
+	}
+
 	if len(deployments) == 0 {
 		fmt.Println("No deployments found.")
 		return nil
@@ -1332,10 +2245,13 @@ func listDeployments(c *cli.Context) error {
 
 	for i, deployment := range deployments {
 		fmt.Printf("%d. %s\n", i+1, deployment.Name)
-		fmt.Printf("   Address: %s\n", deployment.Address.String())
+		fmt.Printf("   Address: %s\n", config.ChecksumAddress(deployment.Address.String()))
 		fmt.Printf("   TX Hash: %s\n", deployment.TransactionHash.String())
-		fmt.Printf("   Deployer: %s\n", deployment.DeployerAddress.String())
+		fmt.Printf("   Deployer: %s\n", config.ChecksumAddress(deployment.DeployerAddress.String()))
 		fmt.Printf("   Deployer Key: %s\n", deployment.DeployerPrivateKey)
+		if deployment.Implementation != nil {
+			fmt.Printf("   Implementation: %s\n", deployment.Implementation.String())
+		}
 		fmt.Printf("   Go binding generation: %v\n", deployment.BindingsPath != "")
 		if deployment.AbiPath != "" {
 			fmt.Printf("   ABI Path: %s\n", deployment.AbiPath)
@@ -1357,11 +2273,18 @@ func getDeploymentInfo(c *cli.Context) error {
 		return fmt.Errorf("failed to get deployment info: %w", err)
 	}
 
+	if jsonOutput(c) {
+		return printJSON(deployment)
+	}
+
 	fmt.Printf("Contract: %s\n", deployment.Name)
-	fmt.Printf("Address: %s\n", deployment.Address.String())
+	fmt.Printf("Address: %s\n", config.ChecksumAddress(deployment.Address.String()))
 	fmt.Printf("Transaction Hash: %s\n", deployment.TransactionHash.String())
-	fmt.Printf("Deployer Address: %s\n", deployment.DeployerAddress.String())
+	fmt.Printf("Deployer Address: %s\n", config.ChecksumAddress(deployment.DeployerAddress.String()))
 	fmt.Printf("Deployer Key: %s\n", deployment.DeployerPrivateKey)
+	if deployment.Implementation != nil {
+		fmt.Printf("Implementation: %s\n", deployment.Implementation.String())
+	}
 	if deployment.AbiPath != "" {
 		fmt.Printf("ABI Path: %s\n", deployment.AbiPath)
 	}
@@ -1372,24 +2295,181 @@ func getDeploymentInfo(c *cli.Context) error {
 	return nil
 }
 
-func cleanupWorkspace(c *cli.Context) error {
-	manager := NewContractManager(c.String("workspace"), "")
+func upgradeProxy(c *cli.Context) error {
+	workspace := c.String("workspace")
+	rpcURL := c.String("rpc-url")
+	proxyName := c.String("proxy")
+	newImpl := c.String("new-impl")
 
-	fmt.Printf("Cleaning up workspace: %s\n", c.String("workspace"))
+	implPath, implName, ok := strings.Cut(newImpl, ":")
+	if !ok || implPath == "" || implName == "" {
+		return fmt.Errorf("invalid --new-impl %q: expected path/to/Contract.sol:ContractName", newImpl)
+	}
 
-	if err := manager.CleanupWorkspace(); err != nil {
-		return fmt.Errorf("failed to cleanup workspace: %w", err)
+	legacy := c.Bool("legacy-upgrade-to")
+	method, _ := upgradeCall(common.Address{}, legacy, nil)
+	if err := checkMethodPolicy(workspace, method); err != nil {
+		return err
 	}
 
-	fmt.Println("Workspace cleaned up successfully")
-	return nil
-}
+	manager := NewContractManager(workspace, rpcURL)
 
-func deployWithCustomScript(c *cli.Context) error {
-	manager := NewContractManager(c.String("workspace"), c.String("rpc-url"))
-	if c.Bool("create-deployer") {
+	proxy, err := manager.GetDeployment(proxyName)
+	if err != nil {
+		return fmt.Errorf("failed to find proxy: %w", err)
+	}
+
+	deployerKey := c.String("deployer-key")
+	if deployerKey == "" {
+		deployerKey = proxy.DeployerPrivateKey
+	}
+	if deployerKey == "" {
+		return fmt.Errorf("deployment record for %s has no deployer key; supply --deployer-key", proxyName)
+	}
+	manager.SetDeployerKey(deployerKey)
+
+	projectDir := c.String("project-dir")
+	if projectDir == "" {
+		projectDir = filepath.Join(workspace, strings.ReplaceAll(strings.ToLower(proxyName), " ", "-"))
+	}
+	absProjectDir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path for %s: %w", projectDir, err)
+	}
+	if _, err := os.Stat(absProjectDir); err != nil {
+		return fmt.Errorf("project directory %s not found: %w", absProjectDir, err)
+	}
+
+	project := &ContractProject{
+		Name:         fmt.Sprintf("%sImplementation", proxyName),
+		ProjectType:  ProjectTypeFoundry,
+		MainContract: implName,
+		ContractPath: implPath,
+		CloneDir:     absProjectDir,
+		Env:          make(map[string]string),
+	}
+
+	fmt.Printf("Deploying new implementation %s...\n", newImpl)
+	impl, err := manager.DeployContract(project, newImpl, nil, false, false)
+	if err != nil {
+		return fmt.Errorf("failed to deploy new implementation: %w", err)
+	}
+	fmt.Printf("New implementation deployed at %s\n", config.ChecksumAddress(impl.Address.String()))
+
+	privateKey, err := parsePrivateKey(deployerKey)
+	if err != nil {
+		return fmt.Errorf("invalid deployer key: %w", err)
+	}
+
+	implAddr := common.HexToAddress(impl.Address.String())
+	txHash, err := sendUpgrade(c.Context, rpcURL, cfg.Token, proxyName, common.HexToAddress(proxy.Address.String()), implAddr, legacy, common.FromHex(c.String("init-data")), privateKey)
+	if err != nil {
+		return err
+	}
+
+	if err := manager.SetImplementationAddress(proxyName, impl.Address); err != nil {
+		return fmt.Errorf("failed to record implementation address: %w", err)
+	}
+
+	fmt.Printf("\nProxy %s upgraded successfully!\n", proxyName)
+	fmt.Printf("Proxy Address: %s\n", config.ChecksumAddress(proxy.Address.String()))
+	fmt.Printf("Implementation: %s\n", config.ChecksumAddress(impl.Address.String()))
+	fmt.Printf("Transaction: %s\n", txHash.Hex())
+	return nil
+}
+
+// upgradeCall returns the proxy method and arguments that point it at impl: upgradeTo with
+// legacy set, otherwise upgradeToAndCall with initData
+func upgradeCall(impl common.Address, legacy bool, initData []byte) (string, []interface{}) {
+	if legacy {
+		return "upgradeTo", []interface{}{impl}
+	}
+	return "upgradeToAndCall", []interface{}{impl, initData}
+}
+
+// sendUpgrade calls the upgrade method on the proxy and waits for it to be mined
+func sendUpgrade(ctx context.Context, rpcURL, token, proxyName string, proxy, impl common.Address, legacy bool, initData []byte, privateKey *ecdsa.PrivateKey) (common.Hash, error) {
+	wrapper, err := config.NewContractWrapper(rpcURL, token, proxy.Hex())
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to create contract wrapper: %w", err)
+	}
+	defer wrapper.Close()
+
+	method, args := upgradeCall(impl, legacy, initData)
+	fmt.Printf("Calling %s.%s(%s)\n", proxyName, method, formatArgs(args))
+	tx, err := wrapper.SendTransaction(ctx, method, args, privateKey, 0, nil)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("upgrade failed: %w", err)
+	}
+	return tx.Hash(), nil
+}
+
+func cleanupWorkspace(c *cli.Context) error {
+	workspace := c.String("workspace")
+	manager := NewContractManager(workspace, "")
+
+	targets, err := manager.CleanupTargets()
+	if err != nil {
+		return fmt.Errorf("failed to cleanup workspace: %w", err)
+	}
+	if len(targets) == 0 {
+		fmt.Printf("Nothing to clean up in %s\n", workspace)
+		return nil
+	}
+	if err := confirmDestructive(c, fmt.Sprintf("The following will be deleted from %s:", workspace), targets); err != nil {
+		return err
+	}
+
+	fmt.Printf("Cleaning up workspace: %s\n", workspace)
+
+	if err := manager.CleanupWorkspace(); err != nil {
+		return fmt.Errorf("failed to cleanup workspace: %w", err)
+	}
+
+	fmt.Println("Workspace cleaned up successfully")
+	return nil
+}
+
+func resetWorkspace(c *cli.Context) error {
+	workspace := c.String("workspace")
+	if _, err := os.Stat(workspace); os.IsNotExist(err) {
+		return fmt.Errorf("workspace %s does not exist", workspace)
+	}
+
+	manager := NewContractManager(workspace, "")
+	keepDeployments, keepAccounts, keepArtifacts := c.Bool("keep-deployments"), c.Bool("keep-accounts"), c.Bool("keep-artifacts")
+
+	targets, err := manager.ResetTargets(keepDeployments, keepAccounts, keepArtifacts)
+	if err != nil {
+		return fmt.Errorf("failed to reset workspace: %w", err)
+	}
+	if len(targets) == 0 {
+		fmt.Printf("Nothing to remove in %s\n", workspace)
+		return nil
+	}
+	if err := confirmDestructive(c, fmt.Sprintf("The following will be deleted from %s:", workspace), targets); err != nil {
+		return err
+	}
+
+	fmt.Printf("Resetting workspace: %s\n", workspace)
+
+	removed, err := manager.ResetWorkspace(keepDeployments, keepAccounts, keepArtifacts)
+	for _, name := range removed {
+		fmt.Printf("  Removed %s\n", name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reset workspace: %w", err)
+	}
+
+	fmt.Printf("Workspace reset (%d entries removed)\n", len(removed))
+	return nil
+}
+
+func deployWithCustomScript(c *cli.Context) error {
+	manager := NewContractManager(c.String("workspace"), c.String("rpc-url"))
+	if c.Bool("create-deployer") {
 		fmt.Println("Creating new deployer account...")
-		privateKey, address, err := manager.CreateDeployerAccount()
+		privateKey, address, err := manager.CreateDeployerAccount(c.Context)
 		if err != nil {
 			return fmt.Errorf("failed to create deployer account: %w", err)
 		}
@@ -1416,206 +2496,888 @@ func deployWithCustomScript(c *cli.Context) error {
 		}
 	}
 
-	fmt.Printf("Cloning repository: %s\n", project.GitURL)
-	if err := manager.CloneRepository(project); err != nil {
-		return fmt.Errorf("failed to clone repository: %w", err)
+	fmt.Printf("Cloning repository: %s\n", project.GitURL)
+	if err := manager.CloneRepository(project); err != nil {
+		return fmt.Errorf("failed to clone repository: %w", err)
+	}
+	fmt.Printf("Repository cloned to: %s\n", project.CloneDir)
+
+	deployScript := c.String("deploy-script")
+	fmt.Printf("Running custom deployment script: %s\n", deployScript)
+	if _, err := manager.RunCustomDeployScript(project, deployScript); err != nil {
+		return fmt.Errorf("failed to run deployment script: %w", err)
+	}
+	fmt.Printf("Custom deployment script completed successfully\n")
+	return nil
+}
+
+func deployWithShellCommands(c *cli.Context) error {
+	manager := NewContractManager(c.String("workspace"), c.String("rpc-url"))
+
+	if c.Bool("create-deployer") {
+		fmt.Println("Creating new deployer account...")
+		privateKey, address, err := manager.CreateDeployerAccount(c.Context)
+		if err != nil {
+			return fmt.Errorf("failed to create deployer account: %w", err)
+		}
+		fmt.Printf("Created deployer account: %s\n", address.String())
+		fmt.Printf("Private key: %s\n", privateKey)
+	} else if deployerKey := c.String("deployer-key"); deployerKey != "" {
+		manager.SetDeployerKey(deployerKey)
+	} else {
+		return fmt.Errorf("either --create-deployer or --deployer-key must be provided")
+	}
+
+	project := &ContractProject{
+		GitURL:   c.String("git-url"),
+		GitRef:   c.String("git-ref"),
+		CloneDir: "",
+		Env:      make(map[string]string),
+	}
+
+	envVars := c.StringSlice("env")
+	for _, envVar := range envVars {
+		parts := strings.SplitN(envVar, "=", 2)
+		if len(parts) == 2 {
+			project.Env[parts[0]] = parts[1]
+		}
+	}
+
+	fmt.Printf("Cloning repository: %s\n", project.GitURL)
+	if err := manager.CloneRepository(project); err != nil {
+		return fmt.Errorf("failed to clone repository: %w", err)
+	}
+	fmt.Printf("Repository cloned to: %s\n", project.CloneDir)
+
+	commands := c.String("commands")
+	fmt.Printf("Running shell commands: %s\n", commands)
+	if err := manager.RunShellCommands(project, commands); err != nil {
+		return fmt.Errorf("failed to run shell commands: %w", err)
+	}
+	fmt.Printf("Shell commands completed successfully\n")
+	return nil
+}
+
+// readCall is a single entry in a batch read
+type readCall struct {
+	Method string   `json:"method"`
+	Args   []string `json:"args,omitempty"`
+}
+
+func callReadMethod(c *cli.Context) error {
+	batch := c.IsSet("calls") || c.IsSet("calls-file")
+	if c.NArg() < 1 || (!batch && c.NArg() < 2) {
+		return fmt.Errorf("usage: contract call read <contract-name> <method-name> [args...]")
+	}
+
+	workspace := "./workspace"
+	contractName := c.Args().Get(0)
+
+	decodeAs, err := parseDecodeTypes(c.String("decode-as"))
+	if err != nil {
+		return err
+	}
+
+	block, err := config.ParseBlockTag(c.String("block"))
+	if err != nil {
+		return err
+	}
+
+	var calls []readCall
+	if batch {
+		calls, err = parseReadCalls(c.String("calls"), c.String("calls-file"))
+		if err != nil {
+			return err
+		}
+	} else {
+		calls = []readCall{{Method: c.Args().Get(1), Args: c.Args().Slice()[2:]}}
+	}
+
+	// A raw address works without a workspace, so a missing deployments.json is not fatal here
+	deployments, err := loadDeployments(workspace)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	contractAddr, err := resolveContractAddress(deployments, contractName)
+	if err != nil {
+		return err
+	}
+
+	for _, call := range calls {
+		if err := checkMethodName(deployments, contractName, call.Method); err != nil {
+			return err
+		}
+		warnMethodMutability(deployments, contractName, call.Method, false)
+	}
+
+	cfg, err := loadWorkspaceConfig(c.Context, workspace)
+	if err != nil {
+		return err
+	}
+
+	wrapper, err := config.NewContractWrapper(cfg.RPC, cfg.Token, contractAddr)
+	if err != nil {
+		return fmt.Errorf("failed to create contract wrapper: %w", err)
+	}
+	defer wrapper.Close()
+	wrapper.SetBlock(block)
+
+	if c.Bool("json") {
+		if batch || c.Bool("watch") {
+			return fmt.Errorf("--json reads a single method and cannot be combined with --calls/--calls-file or --watch")
+		}
+		// Without code the call returns nothing to decode, so fail rather than print a warning
+		if err := checkContractCode(c.Context, wrapper, true); err != nil {
+			return err
+		}
+		return readContractMethodJSON(c.Context, wrapper, deployments, contractName, calls[0], decodeAs)
+	}
+
+	if err := checkContractCode(c.Context, wrapper, c.Bool("strict")); err != nil {
+		return err
+	}
+
+	fmt.Printf("Contract: %s (%s)\n", contractName, contractAddr)
+
+	if c.Bool("watch") {
+		if batch {
+			return fmt.Errorf("--watch reads a single method and cannot be combined with --calls/--calls-file")
+		}
+		return watchContractMethod(c.Context, wrapper, contractName, calls[0], decodeAs, c.Duration("interval"), c.Int("count"), c.Bool("changes-only"))
+	}
+
+	if batch && cfg.Multicall3 != "" {
+		return aggregateReadCalls(c.Context, wrapper, cfg.Multicall3, contractName, calls, decodeAs)
+	}
+
+	var failed int
+	for _, call := range calls {
+		if err := readContractMethod(c.Context, wrapper, workspace, deployments, contractName, call, decodeAs); err != nil {
+			if !batch {
+				return err
+			}
+			fmt.Printf("Error: %v\n", err)
+			failed++
+		}
+		if batch {
+			fmt.Println()
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d calls failed", failed, len(calls))
+	}
+
+	return nil
+}
+
+// readContractMethod performs one eth_call through the wrapper and prints the decoded result.
+// Without --decode-as, methods returning enums are decoded with the deployed ABI so the enum
+// values can be labelled.
+func readContractMethod(ctx context.Context, wrapper *config.ContractWrapper, workspace string, deployments []DeploymentRecord, contractName string, call readCall, decodeAs abi.Arguments) error {
+	args, err := parseArguments(call.Args)
+	if err != nil {
+		return fmt.Errorf("failed to parse arguments: %w", err)
+	}
+
+	fmt.Printf("Calling %s.%s(%v)\n", contractName, call.Method, formatArgs(args))
+
+	result, err := wrapper.CallMethod(ctx, call.Method, args)
+	if err != nil {
+		return fmt.Errorf("call failed: %w", err)
+	}
+
+	if len(decodeAs) == 0 && len(result) > 0 {
+		if enums := loadMethodEnums(workspace, deployments, contractName, call.Method, len(args)); enums != nil {
+			return enums.printResult(call.Method, result)
+		}
+	}
+	return printReadResult(call.Method, result, decodeAs)
+}
+
+// readContractMethodJSON performs one eth_call and prints its outputs as a JSON object. The
+// outputs come from --decode-as when given, otherwise from the deployed contract's ABI.
+func readContractMethodJSON(ctx context.Context, wrapper *config.ContractWrapper, deployments []DeploymentRecord, contractName string, call readCall, decodeAs abi.Arguments) error {
+	args, err := parseArguments(call.Args)
+	if err != nil {
+		return fmt.Errorf("failed to parse arguments: %w", err)
+	}
+
+	outputs := decodeAs
+	if len(outputs) == 0 {
+		record, err := findContractIgnoreCase(deployments, contractName)
+		if err != nil || record.ABIPath == "" {
+			return fmt.Errorf("--json needs the method's output types: pass --decode-as or use a deployed contract with an ABI")
+		}
+		parsedABI, err := loadABI(record.ABIPath)
+		if err != nil {
+			return err
+		}
+		var ok bool
+		outputs, ok = findMethodOutputs(parsedABI, call.Method, len(args))
+		if !ok {
+			return fmt.Errorf("method %s with %d argument(s) not found in %s ABI", call.Method, len(args), record.Name)
+		}
+	}
+
+	result, err := wrapper.CallMethod(ctx, call.Method, args)
+	if err != nil {
+		return fmt.Errorf("call failed: %w", err)
+	}
+	if len(result) == 0 && len(outputs) > 0 {
+		return fmt.Errorf("%s returned no data (possibly not a view function or wrong selector)", call.Method)
+	}
+
+	out, err := decodeOutputsJSON(outputs, result)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal result: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// watchContractMethod re-reads a method every interval and prints each sample, or only the
+// samples whose return data changed, until count samples are taken or ctx is cancelled
+func watchContractMethod(ctx context.Context, wrapper *config.ContractWrapper, contractName string, call readCall, decodeAs abi.Arguments, interval time.Duration, count int, changesOnly bool) error {
+	if interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	args, err := parseArguments(call.Args)
+	if err != nil {
+		return fmt.Errorf("failed to parse arguments: %w", err)
+	}
+
+	fmt.Printf("Watching %s.%s(%v) every %s\n", contractName, call.Method, formatArgs(args), interval)
+
+	var last []byte
+	for sample := 1; count == 0 || sample <= count; sample++ {
+		result, err := wrapper.CallMethod(ctx, call.Method, args)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("call failed: %w", err)
+		}
+
+		if !changesOnly || sample == 1 || !bytes.Equal(result, last) {
+			fmt.Printf("\n[%s] sample %d\n", time.Now().Format(time.RFC3339), sample)
+			if err := printReadResult(call.Method, result, decodeAs); err != nil {
+				return err
+			}
+		}
+		last = result
+
+		if count != 0 && sample == count {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+	return nil
+}
+
+// aggregateReadCalls reads every call in a single Multicall3 eth_call
+func aggregateReadCalls(ctx context.Context, wrapper *config.ContractWrapper, multicallAddress, contractName string, calls []readCall, decodeAs abi.Arguments) error {
+	call3s := make([]config.Call3, len(calls))
+	for i, call := range calls {
+		args, err := parseArguments(call.Args)
+		if err != nil {
+			return fmt.Errorf("failed to parse arguments for %s: %w", call.Method, err)
+		}
+		data, err := wrapper.PackCall(call.Method, args)
+		if err != nil {
+			return fmt.Errorf("failed to build call data for %s: %w", call.Method, err)
+		}
+		call3s[i] = config.Call3{Target: wrapper.Address(), AllowFailure: true, CallData: data}
+	}
+
+	fmt.Printf("Aggregating %d calls via Multicall3 (%s)\n\n", len(calls), multicallAddress)
+
+	results, err := wrapper.Aggregate(ctx, multicallAddress, call3s)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for i, call := range calls {
+		fmt.Printf("Calling %s.%s(%s)\n", contractName, call.Method, strings.Join(call.Args, ", "))
+		if !results[i].Success {
+			fmt.Printf("Error: call reverted\n\n")
+			failed++
+			continue
+		}
+		if err := printReadResult(call.Method, results[i].ReturnData, decodeAs); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			failed++
+		}
+		fmt.Println()
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d calls failed", failed, len(calls))
+	}
+
+	return nil
+}
+
+// printReadResult prints raw return data, decoded as decodeAs when given or as a uint256 otherwise
+func printReadResult(methodName string, result []byte, decodeAs abi.Arguments) error {
+	fmt.Printf("Method: %s\n", methodName)
+
+	// An empty return is not a zero: the method does not exist, is not a view, or reverted without data
+	if len(result) == 0 {
+		fmt.Println("Result: method returned no data (possibly not a view function or wrong selector)")
+		return nil
+	}
+
+	fmt.Printf("Result (hex): 0x%x\n", result)
+
+	if len(decodeAs) == 0 {
+		fmt.Printf("Result (uint256): %s\n", new(big.Int).SetBytes(result).String())
+		return nil
+	}
+
+	values, err := decodeAs.Unpack(result)
+	if err != nil {
+		return fmt.Errorf("failed to decode result as %s: %w", formatDecodeTypes(decodeAs), err)
+	}
+	for i, value := range values {
+		if b, ok := value.([]byte); ok {
+			value = fmt.Sprintf("0x%x", b)
+		}
+		fmt.Printf("Result[%d] (%s): %v\n", i, decodeAs[i].Type.String(), value)
+	}
+	return nil
+}
+
+// parseDecodeTypes parses a --decode-as type list such as "(address,uint256)" or "bytes32"
+func parseDecodeTypes(spec string) (abi.Arguments, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil
+	}
+	if strings.HasPrefix(spec, "(") && strings.HasSuffix(spec, ")") {
+		spec = spec[1 : len(spec)-1]
+	}
+	if strings.ContainsAny(spec, "()") {
+		return nil, fmt.Errorf("invalid --decode-as %q: nested tuples are not supported", spec)
+	}
+
+	var args abi.Arguments
+	for _, t := range strings.Split(spec, ",") {
+		t = strings.TrimSpace(t)
+		typ, err := abi.NewType(t, "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --decode-as type %q: %w", t, err)
+		}
+		args = append(args, abi.Argument{Type: typ})
+	}
+	return args, nil
+}
+
+// formatDecodeTypes renders a decode type list back to its (type,...) form
+func formatDecodeTypes(args abi.Arguments) string {
+	names := make([]string, len(args))
+	for i, arg := range args {
+		names[i] = arg.Type.String()
+	}
+	return "(" + strings.Join(names, ",") + ")"
+}
+
+// parseReadCalls builds the batch call list from the --calls spec and/or a JSON calls file
+func parseReadCalls(spec, file string) ([]readCall, error) {
+	var calls []readCall
+
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read calls file: %w", err)
+		}
+		if err := json.Unmarshal(data, &calls); err != nil {
+			return nil, fmt.Errorf("failed to parse calls file: %w", err)
+		}
+	}
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		method, args, _ := strings.Cut(entry, ":")
+		calls = append(calls, readCall{Method: method, Args: splitCallArgs(args)})
+	}
+
+	for i, call := range calls {
+		if call.Method == "" {
+			return nil, fmt.Errorf("call %d has no method name", i+1)
+		}
+	}
+
+	if len(calls) == 0 {
+		return nil, fmt.Errorf("no calls specified")
+	}
+
+	return calls, nil
+}
+
+// splitCallArgs splits the ':'-separated arguments of a --calls entry, keeping a type:value
+// annotation such as uint8:3 together as one argument
+func splitCallArgs(spec string) []string {
+	if spec == "" {
+		return nil
+	}
+
+	parts := strings.Split(spec, ":")
+	var args []string
+	for i := 0; i < len(parts); i++ {
+		if i+1 < len(parts) && config.IsSolidityType(parts[i]) {
+			args = append(args, parts[i]+":"+parts[i+1])
+			i++
+			continue
+		}
+		args = append(args, parts[i])
+	}
+	return args
+}
+
+func callWriteMethod(c *cli.Context) error {
+	if c.NArg() < 2 {
+		return fmt.Errorf("usage: contract call write <contract-name> <method-name> [args...]")
+	}
+
+	ctx := c.Context
+	workspace := "./workspace"
+
+	allArgs := c.Args().Slice()
+	var contractName, methodName, fromRole string
+	var methodArgs []string
+	gasLimit := c.Uint64("gas")
+	fundAmount := "1"
+	strict := c.Bool("strict")
+	simulate := c.Bool("simulate")
+	send := c.Bool("send")
+	forceNonce := c.Bool("force")
+	var nonceOverride *uint64
+	if c.IsSet("nonce") {
+		n := c.Uint64("nonce")
+		nonceOverride = &n
+	}
+
+	parsedFlags := map[string]string{
+		"from-key":     c.String("from-key"),
+		"gas-price":    c.String("gas-price"),
+		"max-fee":      c.String("max-fee"),
+		"priority-fee": c.String("priority-fee"),
+	}
+	i := 0
+	for i < len(allArgs) {
+		arg := allArgs[i]
+
+		if arg == "--strict" {
+			strict = true
+			i++
+			continue
+		}
+		if arg == "--simulate" {
+			simulate = true
+			i++
+			continue
+		}
+		if arg == "--send" {
+			send = true
+			i++
+			continue
+		}
+		if arg == "--force" {
+			forceNonce = true
+			i++
+			continue
+		}
+		if arg == "--nonce" && i+1 < len(allArgs) {
+			n, err := strconv.ParseUint(allArgs[i+1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid --nonce %q: %w", allArgs[i+1], err)
+			}
+			nonceOverride = &n
+			i += 2
+			continue
+		}
+		if name := strings.TrimPrefix(arg, "--"); (name == "gas-price" || name == "max-fee" || name == "priority-fee") && i+1 < len(allArgs) {
+			parsedFlags[name] = allArgs[i+1]
+			i += 2
+			continue
+		}
+
+		if name := strings.TrimPrefix(arg, "--"); (name == "from" || name == "from-key") && i+1 < len(allArgs) {
+			parsedFlags[name] = allArgs[i+1]
+			i += 2
+			continue
+		}
+		if arg == "--fund" && i+1 < len(allArgs) {
+			parsedFlags["fund"] = allArgs[i+1]
+			i += 2
+			continue
+		}
+		if arg == "--gas" && i+1 < len(allArgs) {
+			val := allArgs[i+1]
+			if gasVal, err := strconv.ParseUint(val, 10, 64); err == nil {
+				gasLimit = gasVal
+			}
+			i += 2
+			continue
+		}
+
+		if contractName == "" {
+			contractName = arg
+		} else if methodName == "" {
+			methodName = arg
+		} else {
+			methodArgs = append(methodArgs, arg)
+		}
+		i++
+	}
+
+	if contractName == "" || methodName == "" {
+		return fmt.Errorf("usage: contract call write <contract-name> <method-name> [args...] [--from <role> | --from-key <hex>] [--fund <amount>] [--gas <limit>] [--gas-price <atto> | --max-fee <atto> --priority-fee <atto>] [--simulate [--send]] [--nonce <n> [--force]]")
+	}
+	if send && !simulate {
+		return fmt.Errorf("--send only applies with --simulate")
+	}
+
+	fees, err := parseFeeOverrides(parsedFlags["gas-price"], parsedFlags["max-fee"], parsedFlags["priority-fee"])
+	if err != nil {
+		return err
+	}
+
+	fromRole = parsedFlags["from"]
+	if val, ok := parsedFlags["fund"]; ok {
+		fundAmount = val
+	}
+
+	deployments, err := loadDeployments(workspace)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	contractAddr, err := resolveContractAddress(deployments, contractName)
+	if err != nil {
+		return err
+	}
+
+	if err := checkMethodName(deployments, contractName, methodName); err != nil {
+		return err
+	}
+	if err := checkMethodPolicy(workspace, methodName); err != nil {
+		return err
+	}
+	warnMethodMutability(deployments, contractName, methodName, true)
+
+	// A raw --from-key bypasses the accounts store; otherwise the role is looked up or created
+	var privateKey *ecdsa.PrivateKey
+	var fromAddr common.Address
+	if fromKey := parsedFlags["from-key"]; fromKey != "" {
+		if fromRole != "" {
+			return fmt.Errorf("--from and --from-key are mutually exclusive")
+		}
+		privateKey, err = parsePrivateKey(fromKey)
+		if err != nil {
+			return fmt.Errorf("invalid --from-key: %w", err)
+		}
+		fromAddr = crypto.PubkeyToAddress(privateKey.PublicKey)
+		fromRole = "raw key"
+	} else {
+		var fromAccount AccountInfo
+		fromRole, fromAccount, err = resolveSenderAccount(ctx, workspace, fromRole, fundAmount)
+		if err != nil {
+			return err
+		}
+		privateKey, err = parsePrivateKey(fromAccount.PrivateKey)
+		if err != nil {
+			return fmt.Errorf("invalid private key for '%s': %w", fromRole, err)
+		}
+		fromAddr = common.HexToAddress(fromAccount.EthAddress)
+	}
+
+	cfg, err := loadWorkspaceConfig(ctx, workspace)
+	if err != nil {
+		return err
+	}
+
+	wrapper, err := config.NewContractWrapper(cfg.RPC, cfg.Token, contractAddr)
+	if err != nil {
+		return fmt.Errorf("failed to create contract wrapper: %w", err)
+	}
+	defer wrapper.Close()
+
+	if err := checkContractCode(ctx, wrapper, strict); err != nil {
+		return err
 	}
-	fmt.Printf("Repository cloned to: %s\n", project.CloneDir)
 
-	deployScript := c.String("deploy-script")
-	fmt.Printf("Running custom deployment script: %s\n", deployScript)
-	if _, err := manager.RunCustomDeployScript(project, deployScript); err != nil {
-		return fmt.Errorf("failed to run deployment script: %w", err)
+	args, err := parseArguments(methodArgs)
+	if err != nil {
+		return fmt.Errorf("failed to parse arguments: %w", err)
 	}
-	fmt.Printf("Custom deployment script completed successfully\n")
-	return nil
-}
 
-func deployWithShellCommands(c *cli.Context) error {
-	manager := NewContractManager(c.String("workspace"), c.String("rpc-url"))
+	if simulate {
+		fmt.Printf("Simulating %s.%s(%v)\n", contractName, methodName, formatArgs(args))
+		fmt.Printf("From: %s (%s)\n", fromRole, fromAddr.Hex())
 
-	if c.Bool("create-deployer") {
-		fmt.Println("Creating new deployer account...")
-		privateKey, address, err := manager.CreateDeployerAccount()
+		callData, err := wrapper.PackCall(methodName, args)
 		if err != nil {
-			return fmt.Errorf("failed to create deployer account: %w", err)
+			return fmt.Errorf("failed to build call data: %w", err)
 		}
-		fmt.Printf("Created deployer account: %s\n", address.String())
-		fmt.Printf("Private key: %s\n", privateKey)
-	} else if deployerKey := c.String("deployer-key"); deployerKey != "" {
-		manager.SetDeployerKey(deployerKey)
-	} else {
-		return fmt.Errorf("either --create-deployer or --deployer-key must be provided")
-	}
+		if err := wrapper.Simulate(ctx, fromAddr, callData, nil); err != nil {
+			return fmt.Errorf("simulation failed: %w", err)
+		}
+		fmt.Println("Simulation succeeded")
 
-	project := &ContractProject{
-		GitURL:   c.String("git-url"),
-		GitRef:   c.String("git-ref"),
-		CloneDir: "",
-		Env:      make(map[string]string),
+		if !send {
+			return nil
+		}
 	}
 
-	envVars := c.StringSlice("env")
-	for _, envVar := range envVars {
-		parts := strings.SplitN(envVar, "=", 2)
-		if len(parts) == 2 {
-			project.Env[parts[0]] = parts[1]
+	fmt.Printf("Sending transaction to %s.%s(%v)\n", contractName, methodName, formatArgs(args))
+	fmt.Printf("From: %s (%s)\n", fromRole, fromAddr.Hex())
+
+	var txHash common.Hash
+	if nonceOverride != nil {
+		current, err := wrapper.PendingNonce(ctx, fromAddr)
+		if err != nil {
+			return err
+		}
+		nonce, err := chooseNonce(current, nonceOverride, forceNonce)
+		if err != nil {
+			return err
+		}
+		callData, err := wrapper.PackCall(methodName, args)
+		if err != nil {
+			return fmt.Errorf("failed to build call data: %w", err)
+		}
+		fmt.Printf("Nonce: %d (current: %d)\n", nonce, current)
+		tx, err := wrapper.SendCallDataWithNonce(ctx, callData, nil, privateKey, nonce, gasLimit, fees)
+		if err != nil {
+			return fmt.Errorf("transaction failed: %w", err)
+		}
+		txHash = tx.Hash()
+	} else {
+		tx, err := wrapper.SendTransaction(ctx, methodName, args, privateKey, gasLimit, fees)
+		if err != nil {
+			return fmt.Errorf("transaction failed: %w", err)
 		}
+		txHash = tx.Hash()
 	}
 
-	fmt.Printf("Cloning repository: %s\n", project.GitURL)
-	if err := manager.CloneRepository(project); err != nil {
-		return fmt.Errorf("failed to clone repository: %w", err)
-	}
-	fmt.Printf("Repository cloned to: %s\n", project.CloneDir)
+	fmt.Printf("Transaction successful: %s\n", txHash.Hex())
 
-	commands := c.String("commands")
-	fmt.Printf("Running shell commands: %s\n", commands)
-	if err := manager.RunShellCommands(project, commands); err != nil {
-		return fmt.Errorf("failed to run shell commands: %w", err)
-	}
-	fmt.Printf("Shell commands completed successfully\n")
 	return nil
 }
 
-func callReadMethod(c *cli.Context) error {
+func profileWriteMethod(c *cli.Context) error {
 	if c.NArg() < 2 {
-		return fmt.Errorf("usage: contract call read <contract-name> <method-name> [args...]")
+		return fmt.Errorf("usage: contract call profile <contract-name> <method-name> [args...]")
 	}
 
-	workspace := "./workspace"
-	contractName := c.Args().Get(0)
-	methodName := c.Args().Get(1)
+	ctx := c.Context
+	workspace := c.String("workspace")
+	contractName, methodName := c.Args().Get(0), c.Args().Get(1)
+	count := c.Int("count")
+	if count < 1 {
+		return fmt.Errorf("--count must be at least 1")
+	}
 
-	methodArgs := []string{}
-	for i := 2; i < c.NArg(); i++ {
-		arg := c.Args().Get(i)
-		methodArgs = append(methodArgs, arg)
+	fees, err := parseFeeOverrides(c.String("gas-price"), c.String("max-fee"), c.String("priority-fee"))
+	if err != nil {
+		return err
 	}
 
 	deployments, err := loadDeployments(workspace)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	contractAddr, err := resolveContractAddress(deployments, contractName)
 	if err != nil {
 		return err
 	}
 
-	var contractAddr string
-	for _, d := range deployments {
-		if strings.EqualFold(d.Name, contractName) {
-			contractAddr = d.Address
-			break
-		}
+	if err := checkMethodName(deployments, contractName, methodName); err != nil {
+		return err
 	}
-	if contractAddr == "" {
-		return fmt.Errorf("contract '%s' not found in deployments", contractName)
+	if err := checkMethodPolicy(workspace, methodName); err != nil {
+		return err
+	}
+
+	fromRole, fromAccount, err := resolveSenderAccount(ctx, workspace, c.String("from"), c.String("fund"))
+	if err != nil {
+		return err
 	}
 
-	cfg, err := loadWorkspaceConfig()
+	cfg, err := loadWorkspaceConfig(ctx, workspace)
 	if err != nil {
 		return err
 	}
 
-	wrapper, err := config.NewContractWrapper(cfg.RPC, contractAddr)
+	wrapper, err := config.NewContractWrapper(cfg.RPC, cfg.Token, contractAddr)
 	if err != nil {
 		return fmt.Errorf("failed to create contract wrapper: %w", err)
 	}
 	defer wrapper.Close()
 
-	args, err := parseArguments(methodArgs)
+	args, err := parseArguments(c.Args().Slice()[2:])
 	if err != nil {
 		return fmt.Errorf("failed to parse arguments: %w", err)
 	}
 
-	fmt.Printf("Calling %s.%s(%v)\n", contractName, methodName, formatArgs(args))
+	callData, err := wrapper.PackCall(methodName, args)
+	if err != nil {
+		return fmt.Errorf("failed to build call data: %w", err)
+	}
 
-	result, err := wrapper.CallMethod(methodName, args)
+	privateKey, err := parsePrivateKey(fromAccount.PrivateKey)
 	if err != nil {
-		return fmt.Errorf("call failed: %w", err)
+		return fmt.Errorf("invalid private key for '%s': %w", fromRole, err)
 	}
 
-	fmt.Printf("Contract: %s (%s)\n", contractName, contractAddr)
-	fmt.Printf("Method: %s\n", methodName)
-	fmt.Printf("Result (hex): 0x%x\n", result)
-	fmt.Printf("Result (uint256): %s\n", new(big.Int).SetBytes(result).String())
+	nonce, err := wrapper.PendingNonce(ctx, common.HexToAddress(fromAccount.EthAddress))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Profiling %s.%s(%v) over %d transaction(s)\n", contractName, methodName, formatArgs(args), count)
+	fmt.Printf("From: %s (%s)\n", fromRole, fromAccount.EthAddress)
+
+	// Nonces are assigned locally so all transactions can be in flight at once
+	hashes := make([]common.Hash, 0, count)
+	for i := 0; i < count; i++ {
+		tx, err := wrapper.SubmitCallDataWithNonce(ctx, callData, nil, privateKey, nonce+uint64(i), c.Uint64("gas"), fees)
+		if err != nil {
+			if len(hashes) == 0 {
+				return fmt.Errorf("transaction %d failed: %w", i+1, err)
+			}
+			fmt.Printf("Warning: transaction %d failed, profiling the %d already sent: %v\n", i+1, len(hashes), err)
+			break
+		}
+		hashes = append(hashes, tx.Hash())
+	}
+
+	// Each receipt is awaited for up to the global --receipt-timeout
+	results := wrapper.WaitForReceipts(ctx, hashes)
+
+	var gasUsed []uint64
+	reverted, unconfirmed := 0, 0
+	for _, r := range results {
+		switch {
+		case r.Reverted():
+			reverted++
+			gasUsed = append(gasUsed, r.Receipt.GasUsed)
+		case r.Confirmed():
+			gasUsed = append(gasUsed, r.Receipt.GasUsed)
+		default:
+			unconfirmed++
+		}
+	}
+
+	fmt.Printf("Sent: %d, mined: %d, reverted: %d, unconfirmed: %d\n", len(hashes), len(gasUsed), reverted, unconfirmed)
+	if len(gasUsed) == 0 {
+		return fmt.Errorf("no receipts received, cannot compute gas statistics")
+	}
 
+	stats := computeGasStats(gasUsed)
+	fmt.Printf("Gas used: min %d, max %d, mean %d, p50 %d, p95 %d\n", stats.Min, stats.Max, stats.Mean, stats.P50, stats.P95)
 	return nil
 }
 
-func callWriteMethod(c *cli.Context) error {
-	if c.NArg() < 2 {
-		return fmt.Errorf("usage: contract call write <contract-name> <method-name> [args...]")
+func sendRawCallData(c *cli.Context) error {
+	ctx := c.Context
+	workspace := c.String("workspace")
+	target := c.String("to")
+
+	dataHex := c.String("data")
+	if !strings.HasPrefix(dataHex, "0x") && !strings.HasPrefix(dataHex, "0X") {
+		return fmt.Errorf("invalid --data: expected 0x-prefixed hex")
+	}
+	callData, err := hex.DecodeString(dataHex[2:])
+	if err != nil {
+		return fmt.Errorf("invalid --data: %w", err)
 	}
 
-	ctx := context.Background()
-	workspace := "./workspace"
+	value, err := types.ParseFIL(c.String("value"))
+	if err != nil {
+		return fmt.Errorf("invalid --value: %w", err)
+	}
 
-	allArgs := c.Args().Slice()
-	var contractName, methodName, fromRole string
-	var methodArgs []string
-	gasLimit := c.Uint64("gas")
-	fundAmount := "1"
+	fees, err := parseFeeOverrides(c.String("gas-price"), c.String("max-fee"), c.String("priority-fee"))
+	if err != nil {
+		return err
+	}
 
-	parsedFlags := make(map[string]string)
-	i := 0
-	for i < len(allArgs) {
-		arg := allArgs[i]
+	deployments, err := loadDeployments(workspace)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
 
-		if arg == "--from" && i+1 < len(allArgs) {
-			parsedFlags["from"] = allArgs[i+1]
-			i += 2
-			continue
-		}
-		if arg == "--fund" && i+1 < len(allArgs) {
-			parsedFlags["fund"] = allArgs[i+1]
-			i += 2
-			continue
-		}
-		if arg == "--gas" && i+1 < len(allArgs) {
-			val := allArgs[i+1]
-			if gasVal, err := strconv.ParseUint(val, 10, 64); err == nil {
-				gasLimit = gasVal
-			}
-			i += 2
-			continue
-		}
+	targetAddr, err := resolveContractAddress(deployments, target)
+	if err != nil {
+		return err
+	}
 
-		if contractName == "" {
-			contractName = arg
-		} else if methodName == "" {
-			methodName = arg
-		} else {
-			methodArgs = append(methodArgs, arg)
-		}
-		i++
+	if err := checkSelectorPolicy(workspace, deployments, targetAddr, callData); err != nil {
+		return err
 	}
 
-	if contractName == "" || methodName == "" {
-		return fmt.Errorf("usage: contract call write <contract-name> <method-name> [args...] [--from <role>] [--fund <amount>] [--gas <limit>]")
+	fromRole, fromAccount, err := resolveSenderAccount(ctx, workspace, c.String("from"), c.String("fund"))
+	if err != nil {
+		return err
 	}
 
-	fromRole = parsedFlags["from"]
-	if val, ok := parsedFlags["fund"]; ok {
-		fundAmount = val
+	privateKey, err := parsePrivateKey(fromAccount.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("invalid private key for '%s': %w", fromRole, err)
 	}
 
-	deployments, err := loadDeployments(workspace)
+	cfg, err := loadWorkspaceConfig(ctx, workspace)
 	if err != nil {
 		return err
 	}
 
-	accounts, err := loadAccounts(workspace)
+	wrapper, err := config.NewContractWrapper(cfg.RPC, cfg.Token, targetAddr)
 	if err != nil {
-		accounts = &AccountsFile{Accounts: make(map[string]AccountInfo)}
+		return fmt.Errorf("failed to create contract wrapper: %w", err)
 	}
+	defer wrapper.Close()
 
-	var contractAddr string
-	for _, d := range deployments {
-		if strings.EqualFold(d.Name, contractName) {
-			contractAddr = d.Address
-			break
-		}
+	fmt.Printf("Sending %d bytes of calldata to %s (%s)\n", len(callData), target, config.ChecksumAddress(targetAddr))
+	if len(callData) >= 4 {
+		fmt.Printf("Selector: 0x%x\n", callData[:4])
+	}
+	fmt.Printf("Value: %s\n", value)
+	fmt.Printf("From: %s (%s)\n", fromRole, fromAccount.EthAddress)
+
+	tx, err := wrapper.SendCallData(ctx, callData, types.BigInt(value).Int, privateKey, c.Uint64("gas"), fees)
+	if err != nil {
+		return fmt.Errorf("transaction failed: %w", err)
 	}
-	if contractAddr == "" {
-		return fmt.Errorf("contract '%s' not found in deployments", contractName)
+
+	fmt.Printf("Transaction successful: %s\n", tx.Hash().Hex())
+	return nil
+}
+
+// resolveSenderAccount returns the workspace account for fromRole, creating, funding, and saving
+// it when it does not exist yet. An empty role uses a new "caller" account.
+func resolveSenderAccount(ctx context.Context, workspace, fromRole, fundAmount string) (string, AccountInfo, error) {
+	accounts, err := loadAccounts(workspace)
+	if err != nil {
+		accounts = &AccountsFile{Accounts: make(map[string]AccountInfo)}
 	}
 
 	var fromAccount AccountInfo
@@ -1626,7 +3388,7 @@ func callWriteMethod(c *cli.Context) error {
 		needsCreation = true
 	} else {
 		var ok bool
-		fromAccount, ok = accounts.Accounts[fromRole]
+		_, fromAccount, ok = findAccount(ctx, accounts, fromRole)
 		if !ok {
 			needsCreation = true
 		}
@@ -1637,39 +3399,40 @@ func callWriteMethod(c *cli.Context) error {
 
 		key, ethAddr, filAddr, err := NewAccount()
 		if err != nil {
-			return fmt.Errorf("failed to create account: %w", err)
+			return "", AccountInfo{}, fmt.Errorf("failed to create account: %w", err)
 		}
 
 		privateKeyHex := fmt.Sprintf("0x%x", key.PrivateKey)
 
 		fromAccount = AccountInfo{
 			Address:    filAddr.String(),
-			EthAddress: ethAddr.String(),
+			EthAddress: config.ChecksumAddress(ethAddr.String()),
 			PrivateKey: privateKeyHex,
 		}
 
 		amount, err := filbig.FromString(fundAmount)
 		if err != nil {
-			return fmt.Errorf("invalid fund amount '%s': %w", fundAmount, err)
+			return "", AccountInfo{}, fmt.Errorf("invalid fund amount '%s': %w", fundAmount, err)
 		}
 		fundAmountAtto := types.BigMul(amount, types.NewInt(1e18))
 
 		fmt.Printf("Funding %s with %s FIL...\n", fromRole, fundAmount)
 		_, err = FundWallet(ctx, filAddr, fundAmountAtto, true)
 		if err != nil {
-			return fmt.Errorf("failed to fund account: %w", err)
+			return "", AccountInfo{}, fmt.Errorf("failed to fund account: %w", err)
 		}
+		fromAccount = recordIDAddress(ctx, fromAccount)
 
 		accounts.Accounts[fromRole] = fromAccount
 
 		accountsPath := filepath.Join(workspace, "accounts.json")
 		accountsData, err := json.MarshalIndent(accounts, "", "  ")
 		if err != nil {
-			return fmt.Errorf("failed to marshal accounts: %w", err)
+			return "", AccountInfo{}, fmt.Errorf("failed to marshal accounts: %w", err)
 		}
 
 		if err := os.WriteFile(accountsPath, accountsData, 0644); err != nil {
-			return fmt.Errorf("failed to save accounts: %w", err)
+			return "", AccountInfo{}, fmt.Errorf("failed to save accounts: %w", err)
 		}
 
 		fmt.Printf("Account '%s' created and saved: %s\n", fromRole, ethAddr.String())
@@ -1678,45 +3441,171 @@ func callWriteMethod(c *cli.Context) error {
 		time.Sleep(5 * time.Second)
 	}
 
-	cfg, err := loadWorkspaceConfig()
+	return fromRole, fromAccount, nil
+}
+
+// resolveContractAddress returns the deployed address for a contract name, or validates and returns a raw 0x address
+func resolveContractAddress(deployments []DeploymentRecord, nameOrAddress string) (string, error) {
+	if strings.HasPrefix(nameOrAddress, "0x") || strings.HasPrefix(nameOrAddress, "0X") {
+		addr, err := parseHexAddress("contract", nameOrAddress)
+		if err != nil {
+			return "", err
+		}
+		return addr.Hex(), nil
+	}
+
+	for _, d := range deployments {
+		if strings.EqualFold(d.Name, nameOrAddress) {
+			return d.Address, nil
+		}
+	}
+	return "", fmt.Errorf("contract '%s' not found in deployments", nameOrAddress)
+}
+
+// parseHexAddress validates a user-supplied 0x address, naming the offending input in the error
+func parseHexAddress(name, value string) (common.Address, error) {
+	addr, err := config.ParseAddress(value)
 	if err != nil {
-		return err
+		return common.Address{}, fmt.Errorf("invalid %s address: %w", name, err)
+	}
+	return addr, nil
+}
+
+// verifyChainID checks that the RPC endpoint serves the expected chain; expected 0 skips the check
+func verifyChainID(ctx context.Context, rpcURL string, expected int64) error {
+	if expected == 0 {
+		return nil
 	}
 
-	wrapper, err := config.NewContractWrapper(cfg.RPC, contractAddr)
+	client, err := config.DialEthClient(rpcURL, cfg.Token)
 	if err != nil {
-		return fmt.Errorf("failed to create contract wrapper: %w", err)
+		return fmt.Errorf("failed to connect to %s: %w", rpcURL, err)
 	}
-	defer wrapper.Close()
+	defer client.Close()
 
-	args, err := parseArguments(methodArgs)
+	chainID, err := client.ChainID(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to parse arguments: %w", err)
+		return fmt.Errorf("failed to get chain ID from %s: %w", rpcURL, err)
 	}
+	if chainID.Int64() != expected {
+		return fmt.Errorf("chain ID mismatch: %s reports %s, config expects %d", rpcURL, chainID, expected)
+	}
+	return nil
+}
 
-	privateKey, err := parsePrivateKey(fromAccount.PrivateKey)
+// checkContractCode warns, or fails when strict, if the wrapper's target has no deployed code
+func checkContractCode(ctx context.Context, wrapper *config.ContractWrapper, strict bool) error {
+	hasCode, err := wrapper.HasCode(ctx)
 	if err != nil {
-		return fmt.Errorf("invalid private key for '%s': %w", fromRole, err)
+		return fmt.Errorf("failed to check contract code: %w", err)
+	}
+	if hasCode {
+		return nil
 	}
 
-	fmt.Printf("Sending transaction to %s.%s(%v)\n", contractName, methodName, formatArgs(args))
-	fmt.Printf("From: %s (%s)\n", fromRole, fromAccount.EthAddress)
+	msg := fmt.Sprintf("no contract code at %s (address is an EOA or not yet deployed)", wrapper.Address().Hex())
+	if strict {
+		return fmt.Errorf("%s", msg)
+	}
+	fmt.Printf("Warning: %s\n", msg)
+	return nil
+}
+
+// splitConstructorArgs splits a --constructor-args value into individual arguments.
+// A JSON array is taken as-is; otherwise values are comma-separated and may be
+// double-quoted to keep embedded commas (e.g. 60,"Storage, with CDN",0x...).
+func splitConstructorArgs(argsStr string) ([]string, error) {
+	argsStr = strings.TrimSpace(argsStr)
+	if argsStr == "" {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(argsStr, "[") {
+		var raw []json.RawMessage
+		if err := json.Unmarshal([]byte(argsStr), &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON array: %w", err)
+		}
+		args := make([]string, len(raw))
+		for i, r := range raw {
+			var str string
+			if err := json.Unmarshal(r, &str); err == nil {
+				args[i] = str
+			} else {
+				args[i] = string(r)
+			}
+		}
+		return args, nil
+	}
 
-	tx, err := wrapper.SendTransaction(methodName, args, privateKey, gasLimit)
+	reader := csv.NewReader(strings.NewReader(argsStr))
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	args, err := reader.Read()
 	if err != nil {
-		return fmt.Errorf("transaction failed: %w", err)
+		return nil, err
+	}
+	for i, arg := range args {
+		args[i] = strings.TrimSpace(arg)
 	}
 
-	fmt.Printf("Transaction successful: %s\n", tx.Hash().Hex())
+	return args, nil
+}
 
-	return nil
+// parseFeeOverrides converts the fee flags into wrapper overrides, returning nil when none are set
+func parseFeeOverrides(gasPrice, maxFee, priorityFee string) (*config.FeeOverrides, error) {
+	if gasPrice == "" && maxFee == "" && priorityFee == "" {
+		return nil, nil
+	}
+	if gasPrice != "" && (maxFee != "" || priorityFee != "") {
+		return nil, fmt.Errorf("--gas-price cannot be combined with --max-fee/--priority-fee")
+	}
+
+	parse := func(name, value string) (*big.Int, error) {
+		if value == "" {
+			return nil, nil
+		}
+		v, ok := new(big.Int).SetString(value, 10)
+		if !ok || v.Sign() < 0 {
+			return nil, fmt.Errorf("invalid --%s: %s", name, value)
+		}
+		return v, nil
+	}
+
+	fees := &config.FeeOverrides{}
+	var err error
+	if fees.GasPrice, err = parse("gas-price", gasPrice); err != nil {
+		return nil, err
+	}
+	if fees.MaxFee, err = parse("max-fee", maxFee); err != nil {
+		return nil, err
+	}
+	if fees.PriorityFee, err = parse("priority-fee", priorityFee); err != nil {
+		return nil, err
+	}
+
+	return fees, nil
 }
 
+// parseArguments converts CLI arguments to call values. An argument may force its
+// Solidity type with a type:value annotation (e.g. uint8:5, string:0xdeadbeef);
+// otherwise the type is inferred from the literal.
 func parseArguments(args []string) ([]interface{}, error) {
 	parsed := make([]interface{}, len(args))
 
 	for i, arg := range args {
+		if argType, value, ok := strings.Cut(arg, ":"); ok && config.IsSolidityType(argType) {
+			typed, err := config.ConvertTypedArgument(value, argType)
+			if err != nil {
+				return nil, fmt.Errorf("invalid argument %d (%s): %w", i+1, arg, err)
+			}
+			parsed[i] = typed
+			continue
+		}
+
 		if strings.HasPrefix(arg, "0x") && len(arg) == 42 {
+			if err := config.ValidateChecksum(arg); err != nil {
+				return nil, fmt.Errorf("invalid argument %d: %w", i+1, err)
+			}
 			parsed[i] = common.HexToAddress(arg)
 		} else if arg == "true" || arg == "false" {
 			parsed[i] = arg == "true"
@@ -1737,6 +3626,10 @@ func formatArgs(args []interface{}) string {
 
 	formatted := make([]string, len(args))
 	for i, arg := range args {
+		if typed, ok := arg.(config.TypedArg); ok {
+			formatted[i] = fmt.Sprintf("%s:%s", typed.Type, formatArgs([]interface{}{typed.Value}))
+			continue
+		}
 		switch v := arg.(type) {
 		case common.Address:
 			formatted[i] = v.Hex()
@@ -1746,6 +3639,10 @@ func formatArgs(args []interface{}) string {
 			formatted[i] = fmt.Sprintf("%v", v)
 		case string:
 			formatted[i] = fmt.Sprintf(`"%s"`, v)
+		case []byte:
+			formatted[i] = "0x" + hex.EncodeToString(v)
+		case config.EncodedValue:
+			formatted[i] = "0x" + hex.EncodeToString(v.Encoded)
 		default:
 			formatted[i] = fmt.Sprintf("%v", v)
 		}
@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli/v2"
+)
+
+func TestSendUpgrade(t *testing.T) {
+	proxy := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	impl := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	initData := []byte{0xca, 0xfe}
+
+	tests := []struct {
+		name   string
+		legacy bool
+		method string
+		types  string
+		args   []interface{}
+	}{
+		{name: "upgradeToAndCall", method: "upgradeToAndCall", types: "address,bytes", args: []interface{}{impl, initData}},
+		{name: "legacy upgradeTo", legacy: true, method: "upgradeTo", types: "address", args: []interface{}{impl}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, url := newFakeEthNode(t)
+			key, _ := testSender(t)
+			privateKey, err := parsePrivateKey(key)
+			if err != nil {
+				t.Fatalf("parsePrivateKey: %v", err)
+			}
+
+			if _, err := sendUpgrade(context.Background(), url, "", "Proxy", proxy, impl, tt.legacy, initData, privateKey); err != nil {
+				t.Fatalf("sendUpgrade: %v", err)
+			}
+
+			sent := node.transactions()
+			if len(sent) != 1 {
+				t.Fatalf("node received %d transactions, want 1", len(sent))
+			}
+			if to := sent[0].To(); to == nil || *to != proxy {
+				t.Errorf("upgrade sent to %v, want the proxy %s", to, proxy.Hex())
+			}
+			var arguments abi.Arguments
+			for _, name := range strings.Split(tt.types, ",") {
+				typ, err := abi.NewType(name, "", nil)
+				if err != nil {
+					t.Fatalf("invalid type %s: %v", name, err)
+				}
+				arguments = append(arguments, abi.Argument{Type: typ})
+			}
+			packed, err := arguments.Pack(tt.args...)
+			if err != nil {
+				t.Fatalf("failed to pack arguments: %v", err)
+			}
+			signature := tt.method + "(" + tt.types + ")"
+			want := append(crypto.Keccak256([]byte(signature))[:4], packed...)
+			if !bytes.Equal(sent[0].Data(), want) {
+				t.Errorf("calldata = %x, want %s pointing at the new implementation (%x)", sent[0].Data(), signature, want)
+			}
+		})
+	}
+}
+
+func TestUpgradeProxyChecksMethodPolicy(t *testing.T) {
+	workspace := testWorkspace(t, &WorkspaceSettings{DeniedMethods: []string{"upgradeToAndCall"}})
+
+	var upgrade *cli.Command
+	for _, sub := range ContractCmd.Subcommands {
+		if sub.Name == "upgrade" {
+			upgrade = sub
+		}
+	}
+	if upgrade == nil {
+		t.Fatal("contract upgrade command not found")
+	}
+
+	// The policy is checked before the proxy is looked up or anything is deployed
+	app := &cli.App{Commands: []*cli.Command{upgrade}}
+	err := app.Run([]string{"filwizard", "upgrade", "--proxy", "Proxy", "--new-impl", "src/Impl.sol:Impl", "--workspace", workspace})
+	if err == nil || !strings.Contains(err.Error(), "blocked by denied_methods") {
+		t.Errorf("error = %v, want the method policy to block upgradeToAndCall", err)
+	}
+}
+
+func TestParseReadCalls(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    []readCall
+		wantErr string
+	}{
+		{
+			name: "methods and plain arguments",
+			spec: "name, balanceOf:0x00000000000000000000000000000000000000aa",
+			want: []readCall{{Method: "name"}, {Method: "balanceOf", Args: []string{"0x00000000000000000000000000000000000000aa"}}},
+		},
+		{
+			name: "type annotations stay one argument",
+			spec: "getRole:uint8:3:bytes32:admin,allowance:address:0x00000000000000000000000000000000000000aa:0x00000000000000000000000000000000000000bb",
+			want: []readCall{
+				{Method: "getRole", Args: []string{"uint8:3", "bytes32:admin"}},
+				{Method: "allowance", Args: []string{"address:0x00000000000000000000000000000000000000aa", "0x00000000000000000000000000000000000000bb"}},
+			},
+		},
+		{
+			name: "a trailing type name is a plain argument",
+			spec: "lookup:address",
+			want: []readCall{{Method: "lookup", Args: []string{"address"}}},
+		},
+		{name: "missing method", spec: ":1", wantErr: "call 1 has no method name"},
+		{name: "no calls", spec: " , ", wantErr: "no calls specified"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseReadCalls(tt.spec, "")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseReadCalls: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeployOutcomeFail(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	tests := []struct {
+		name      string
+		keepGoing bool
+		wantAbort bool
+	}{
+		{name: "fail fast", keepGoing: false, wantAbort: true},
+		{name: "keep going", keepGoing: true, wantAbort: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcome := &deployOutcome{keepGoing: tt.keepGoing}
+
+			err := outcome.fail("Token", errBoom)
+			if tt.wantAbort {
+				if err == nil {
+					t.Fatal("fail returned nil, want an error that aborts the run")
+				}
+				if !errors.Is(err, errBoom) {
+					t.Errorf("fail error %v does not wrap the contract error", err)
+				}
+			} else if err != nil {
+				t.Fatalf("fail returned %v, want nil with --keep-going", err)
+			}
+
+			if len(outcome.failures) != 1 || outcome.failures[0].Name != "Token" {
+				t.Errorf("failures = %+v, want one failure for Token", outcome.failures)
+			}
+		})
+	}
+}
+
+func TestDeployOutcomeKeepGoingRecordsEveryFailure(t *testing.T) {
+	outcome := &deployOutcome{keepGoing: true}
+	for _, name := range []string{"Token", "Payments", "Registry"} {
+		if err := outcome.fail(name, errors.New("failed")); err != nil {
+			t.Fatalf("fail(%s) = %v, want nil", name, err)
+		}
+	}
+
+	if len(outcome.failures) != 3 {
+		t.Fatalf("got %d failures, want 3", len(outcome.failures))
+	}
+	for i, want := range []string{"Token", "Payments", "Registry"} {
+		if outcome.failures[i].Name != want {
+			t.Errorf("failures[%d] = %s, want %s", i, outcome.failures[i].Name, want)
+		}
+	}
+}
+
+func TestPrintDeployResultJSON(t *testing.T) {
+	deployed := &deployResult{
+		Name:         "SimpleCoin",
+		Address:      "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		Deployer:     "t410f123",
+		TxHash:       "0xabc",
+		GasUsed:      21000,
+		BytecodePath: "workspace/contracts/simplecoin.bin",
+		ABIPath:      "workspace/contracts/simplecoin.abi.json",
+	}
+
+	tests := []struct {
+		name   string
+		result *deployResult
+		err    error
+		want   map[string]interface{}
+	}{
+		{
+			name:   "success",
+			result: deployed,
+			want: map[string]interface{}{
+				"name":         "SimpleCoin",
+				"address":      "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+				"deployer":     "t410f123",
+				"txHash":       "0xabc",
+				"gasUsed":      float64(21000),
+				"bytecodePath": "workspace/contracts/simplecoin.bin",
+				"abiPath":      "workspace/contracts/simplecoin.abi.json",
+			},
+		},
+		{
+			name:   "failure keeps partial result",
+			result: &deployResult{Name: "SimpleCoin", TxHash: "0xabc"},
+			err:    errors.New("transaction failed with status: 0"),
+			want: map[string]interface{}{
+				"name":   "SimpleCoin",
+				"txHash": "0xabc",
+				"error":  "transaction failed with status: 0",
+			},
+		},
+		{
+			name: "failure before any result",
+			err:  errors.New("expected 1 argument: <contract-file>"),
+			want: map[string]interface{}{"error": "expected 1 argument: <contract-file>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := printDeployResultJSON(&out, tt.result, tt.err)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("error = %v, want %v", err, tt.err)
+			}
+
+			var got map[string]interface{}
+			if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+				t.Fatalf("output is not a single JSON object: %v\n%s", err, out.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("JSON = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/parthshah1/mpool-tx/config"
+	"github.com/urfave/cli/v2"
+)
+
+// FieldChange is a single metadata difference between two deployments of the same contract
+type FieldChange struct {
+	Field string `json:"field"`
+	A     string `json:"a"`
+	B     string `json:"b"`
+}
+
+// DeploymentDiff reports how the deployments of two workspaces differ
+type DeploymentDiff struct {
+	OnlyInA []string                 `json:"only_in_a"`
+	OnlyInB []string                 `json:"only_in_b"`
+	Changed map[string][]FieldChange `json:"changed"`
+}
+
+// Empty reports whether both workspaces hold the same contract set and metadata
+func (d *DeploymentDiff) Empty() bool {
+	return len(d.OnlyInA) == 0 && len(d.OnlyInB) == 0 && len(d.Changed) == 0
+}
+
+// DiffDeployments compares two deployment sets by contract name. ABI and bindings
+// paths are workspace-local and ignored; the latest record wins for repeated names.
+func DiffDeployments(a, b []config.DeploymentRecord) *DeploymentDiff {
+	latestA := latestDeploymentsByName(a)
+	latestB := latestDeploymentsByName(b)
+
+	diff := &DeploymentDiff{
+		OnlyInA: []string{},
+		OnlyInB: []string{},
+		Changed: make(map[string][]FieldChange),
+	}
+
+	for key, recA := range latestA {
+		recB, ok := latestB[key]
+		if !ok {
+			diff.OnlyInA = append(diff.OnlyInA, recA.Name)
+			continue
+		}
+
+		fields := []struct {
+			name string
+			a, b string
+		}{
+			{"address", recA.Address, recB.Address},
+			{"deployer_address", recA.DeployerAddress, recB.DeployerAddress},
+			{"implementation_address", recA.Implementation, recB.Implementation},
+			{"config_hash", recA.ConfigHash, recB.ConfigHash},
+		}
+		for _, f := range fields {
+			if !strings.EqualFold(f.a, f.b) {
+				diff.Changed[recA.Name] = append(diff.Changed[recA.Name], FieldChange{Field: f.name, A: f.a, B: f.b})
+			}
+		}
+	}
+
+	for key, recB := range latestB {
+		if _, ok := latestA[key]; !ok {
+			diff.OnlyInB = append(diff.OnlyInB, recB.Name)
+		}
+	}
+
+	sort.Strings(diff.OnlyInA)
+	sort.Strings(diff.OnlyInB)
+
+	return diff
+}
+
+func latestDeploymentsByName(deployments []config.DeploymentRecord) map[string]config.DeploymentRecord {
+	latest := make(map[string]config.DeploymentRecord)
+	for _, d := range deployments {
+		latest[strings.ToLower(d.Name)] = d
+	}
+	return latest
+}
+
+func diffWorkspaces(c *cli.Context) error {
+	workspaceA := c.String("workspace-a")
+	workspaceB := c.String("workspace-b")
+
+	deploymentsA, err := config.LoadDeploymentRecords(filepath.Join(workspaceA, "deployments.json"))
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", workspaceA, err)
+	}
+	deploymentsB, err := config.LoadDeploymentRecords(filepath.Join(workspaceB, "deployments.json"))
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", workspaceB, err)
+	}
+
+	diff := DiffDeployments(deploymentsA, deploymentsB)
+
+	if jsonOutput(c) {
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal diff: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("A: %s (%d contracts)\n", workspaceA, len(latestDeploymentsByName(deploymentsA)))
+	fmt.Printf("B: %s (%d contracts)\n\n", workspaceB, len(latestDeploymentsByName(deploymentsB)))
+
+	if diff.Empty() {
+		fmt.Println("Deployments match")
+		return nil
+	}
+
+	for _, name := range diff.OnlyInA {
+		fmt.Printf("- %s (only in A)\n", name)
+	}
+	for _, name := range diff.OnlyInB {
+		fmt.Printf("+ %s (only in B)\n", name)
+	}
+
+	names := make([]string, 0, len(diff.Changed))
+	for name := range diff.Changed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("~ %s\n", name)
+		for _, change := range diff.Changed[name] {
+			fmt.Printf("    %s: %s -> %s\n", change.Field, change.A, change.B)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/parthshah1/mpool-tx/config"
+	"github.com/urfave/cli/v2"
+)
+
+// doctorTools are the external binaries checked by doctor
+var doctorTools = []string{"forge", "solc", "abigen", "cast"}
+
+// DoctorCheck is the outcome of a single environment check
+type DoctorCheck struct {
+	Name   string
+	Detail string
+	Err    error
+	Hint   string
+}
+
+// Passed reports whether the check succeeded
+func (d DoctorCheck) Passed() bool {
+	return d.Err == nil
+}
+
+var DoctorCmd = &cli.Command{
+	Name:  "doctor",
+	Usage: "Check the node connection, chain ID, tools, default wallet, and workspace",
+	Flags: []cli.Flag{
+		&cli.Int64Flag{
+			Name:  "chain-id",
+			Usage: "Expected chain ID",
+			Value: config.DefaultChainID,
+		},
+		&cli.StringFlag{
+			Name:  "workspace",
+			Usage: "Workspace directory to check for write access",
+			Value: "./workspace",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		checks := runDoctorChecks(c.Context, c.Int64("chain-id"), c.String("workspace"))
+
+		failed := printDoctorChecks(c.App.Writer, checks)
+		if failed > 0 {
+			return fmt.Errorf("%d of %d check(s) failed", failed, len(checks))
+		}
+		return nil
+	},
+}
+
+// runDoctorChecks runs every environment check. Checks are independent: a failure is reported
+// and the remaining checks still run, with node-dependent checks failing if the node is unreachable.
+func runDoctorChecks(ctx context.Context, chainID int64, workspace string) []DoctorCheck {
+	var checks []DoctorCheck
+
+	nodeCheck := checkNodeConnection(ctx)
+	checks = append(checks, nodeCheck)
+	checks = append(checks, checkChainID(ctx, chainID))
+	for _, tool := range doctorTools {
+		checks = append(checks, checkTool(tool))
+	}
+	if nodeCheck.Passed() {
+		checks = append(checks, checkDefaultWallet(ctx))
+	} else {
+		checks = append(checks, DoctorCheck{
+			Name: "Default wallet funded",
+			Err:  fmt.Errorf("skipped: node unreachable"),
+			Hint: "fix the RPC connection first",
+		})
+	}
+	checks = append(checks, checkWorkspaceWritable(workspace))
+
+	return checks
+}
+
+func checkNodeConnection(ctx context.Context) DoctorCheck {
+	check := DoctorCheck{
+		Name: "RPC connectivity",
+		Hint: fmt.Sprintf("check the node is running at %s (--rpc / FILECOIN_RPC) and the token is valid (--token / FILECOIN_TOKEN)", cfg.RPC),
+	}
+
+	client, err := config.New(cfg)
+	if err != nil {
+		check.Err = err
+		return check
+	}
+
+	head, err := client.GetAPI().ChainHead(ctx)
+	if err != nil {
+		client.Close()
+		check.Err = fmt.Errorf("ChainHead failed: %w", err)
+		return check
+	}
+
+	clientt = client
+	check.Detail = fmt.Sprintf("%s, head at epoch %d", cfg.RPC, head.Height())
+	return check
+}
+
+func checkChainID(ctx context.Context, expected int64) DoctorCheck {
+	check := DoctorCheck{
+		Name: "Chain ID",
+		Hint: "point --rpc at the intended network, or pass --chain-id if this network is expected",
+	}
+
+	client, err := config.DialEthClient(cfg.RPC, cfg.Token)
+	if err != nil {
+		check.Err = fmt.Errorf("failed to connect: %w", err)
+		return check
+	}
+	defer client.Close()
+
+	detected, err := client.ChainID(ctx)
+	if err != nil {
+		check.Err = fmt.Errorf("eth_chainId failed: %w", err)
+		return check
+	}
+	if detected.Int64() != expected {
+		check.Err = fmt.Errorf("node reports chain ID %s, expected %d", detected, expected)
+		return check
+	}
+
+	check.Detail = detected.String()
+	return check
+}
+
+func checkTool(tool string) DoctorCheck {
+	check := DoctorCheck{
+		Name: fmt.Sprintf("%s installed", tool),
+		Hint: toolInstallHints[tool],
+	}
+
+	path, err := exec.LookPath(tool)
+	if err != nil {
+		check.Err = fmt.Errorf("%s not found on PATH", tool)
+		return check
+	}
+	check.Detail = path
+	return check
+}
+
+func checkDefaultWallet(ctx context.Context) DoctorCheck {
+	check := DoctorCheck{
+		Name: "Default wallet funded",
+		Hint: "set a funded default wallet on the node with `lotus wallet set-default <address>`",
+	}
+
+	addr, err := clientt.GetAPI().WalletDefaultAddress(ctx)
+	if err != nil {
+		check.Err = fmt.Errorf("failed to get default wallet: %w", err)
+		return check
+	}
+
+	balance, err := clientt.GetAPI().WalletBalance(ctx, addr)
+	if err != nil {
+		check.Err = fmt.Errorf("failed to get balance of %s: %w", addr, err)
+		return check
+	}
+
+	minBalance := big.NewInt(cfg.MinBalance)
+	if balance.LessThan(minBalance) {
+		check.Err = fmt.Errorf("%s has %s, below the minimum %s (MIN_WALLET_BALANCE)", addr, types.FIL(balance), types.FIL(minBalance))
+		return check
+	}
+
+	check.Detail = fmt.Sprintf("%s has %s", addr, types.FIL(balance))
+	return check
+}
+
+func checkWorkspaceWritable(workspace string) DoctorCheck {
+	check := DoctorCheck{
+		Name: "Workspace writable",
+		Hint: fmt.Sprintf("create %s or pass a writable --workspace", workspace),
+	}
+
+	if err := os.MkdirAll(workspace, 0755); err != nil {
+		check.Err = fmt.Errorf("failed to create %s: %w", workspace, err)
+		return check
+	}
+
+	f, err := os.CreateTemp(workspace, ".doctor-*")
+	if err != nil {
+		check.Err = fmt.Errorf("cannot write to %s: %w", workspace, err)
+		return check
+	}
+	f.Close()
+	os.Remove(f.Name())
+
+	abs, err := filepath.Abs(workspace)
+	if err != nil {
+		abs = workspace
+	}
+	check.Detail = abs
+	return check
+}
+
+// printDoctorChecks writes the checklist and returns the number of failed checks
+func printDoctorChecks(w io.Writer, checks []DoctorCheck) int {
+	failed := 0
+	for _, check := range checks {
+		if check.Passed() {
+			fmt.Fprintf(w, "[PASS] %s", check.Name)
+			if check.Detail != "" {
+				fmt.Fprintf(w, ": %s", check.Detail)
+			}
+			fmt.Fprintln(w)
+			continue
+		}
+
+		failed++
+		fmt.Fprintf(w, "[FAIL] %s: %v\n", check.Name, check.Err)
+		if check.Hint != "" {
+			fmt.Fprintf(w, "       -> %s\n", check.Hint)
+		}
+	}
+	return failed
+}
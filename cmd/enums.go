@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// methodEnums records which outputs of a method are Solidity enums. The parsed ABI only keeps
+// their uint8 encoding, so the enum names come from the internalType fields of the ABI file.
+type methodEnums struct {
+	outputs abi.Arguments
+	types   []string            // enum type of each output, e.g. Market.Status, or "" for non-enums
+	labels  map[string][]string // member names by enum type, from workspace.json
+}
+
+// abiFunctionEntry is the part of a raw ABI entry needed to find enum outputs
+type abiFunctionEntry struct {
+	Type    string     `json:"type"`
+	Name    string     `json:"name"`
+	Inputs  []struct{} `json:"inputs"`
+	Outputs []struct {
+		InternalType string `json:"internalType"`
+	} `json:"outputs"`
+}
+
+// loadMethodEnums returns the enum outputs of method (with nargs inputs) in the deployed
+// contract's ABI, or nil when the contract has no ABI or the method returns no enums
+func loadMethodEnums(workspace string, deployments []DeploymentRecord, nameOrAddress, method string, nargs int) *methodEnums {
+	record, err := findContractIgnoreCase(deployments, nameOrAddress)
+	if err != nil || record.ABIPath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(record.ABIPath)
+	if err != nil {
+		return nil
+	}
+	var entries []abiFunctionEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil
+	}
+
+	var types []string
+	for _, entry := range entries {
+		if entry.Type != "function" || entry.Name != method || len(entry.Inputs) != nargs {
+			continue
+		}
+		found := false
+		types = make([]string, len(entry.Outputs))
+		for i, output := range entry.Outputs {
+			if name, ok := strings.CutPrefix(output.InternalType, "enum "); ok {
+				types[i] = name
+				found = true
+			}
+		}
+		if !found {
+			return nil
+		}
+		break
+	}
+	if types == nil {
+		return nil
+	}
+
+	parsedABI, err := loadABI(record.ABIPath)
+	if err != nil {
+		return nil
+	}
+	outputs, ok := findMethodOutputs(parsedABI, method, nargs)
+	if !ok || len(outputs) != len(types) {
+		return nil
+	}
+
+	enums := &methodEnums{outputs: outputs, types: types}
+	if settings, err := loadWorkspaceSettings(workspace); err == nil && settings != nil {
+		enums.labels = settings.Enums
+	}
+	return enums
+}
+
+// label formats an enum value as "Name (n)" when its member names are configured, by full
+// type name (Market.Status) or bare name (Status), and as the number otherwise
+func (e *methodEnums) label(enumType string, value uint8) string {
+	names, ok := e.labels[enumType]
+	if !ok {
+		_, short, _ := strings.Cut(enumType, ".")
+		names = e.labels[short]
+	}
+	if int(value) < len(names) {
+		return fmt.Sprintf("%s (%d)", names[value], value)
+	}
+	return fmt.Sprintf("%d", value)
+}
+
+// printResult prints return data decoded with the method's ABI outputs, labelling enums
+func (e *methodEnums) printResult(methodName string, result []byte) error {
+	fmt.Printf("Method: %s\n", methodName)
+	fmt.Printf("Result (hex): 0x%x\n", result)
+
+	values, err := e.outputs.Unpack(result)
+	if err != nil {
+		return fmt.Errorf("failed to decode result as %s: %w", formatDecodeTypes(e.outputs), err)
+	}
+	for i, value := range values {
+		typeName := e.outputs[i].Type.String()
+		if n, ok := value.(uint8); ok && e.types[i] != "" {
+			typeName = "enum " + e.types[i]
+			value = e.label(e.types[i], n)
+		} else if b, ok := value.([]byte); ok {
+			value = fmt.Sprintf("0x%x", b)
+		}
+		fmt.Printf("Result[%d] (%s): %v\n", i, typeName, value)
+	}
+	return nil
+}
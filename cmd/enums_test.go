@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testMarketABI has a status() method returning the enum Market.Status
+const testMarketABI = `[
+  {"type":"function","name":"status","stateMutability":"view","inputs":[],
+   "outputs":[{"name":"","type":"uint8","internalType":"enum Market.Status"}]},
+  {"type":"function","name":"fee","stateMutability":"view","inputs":[],
+   "outputs":[{"name":"","type":"uint8","internalType":"uint8"}]}
+]`
+
+func TestMethodEnumLabel(t *testing.T) {
+	abiPath := filepath.Join(t.TempDir(), "Market.abi.json")
+	if err := os.WriteFile(abiPath, []byte(testMarketABI), 0644); err != nil {
+		t.Fatalf("failed to write ABI: %v", err)
+	}
+	deployments := []DeploymentRecord{{Name: "Market", Address: testTokenAddress, ABIPath: abiPath}}
+	statuses := []string{"Open", "Closed", "Settled"}
+
+	tests := []struct {
+		name     string
+		settings *WorkspaceSettings
+		value    uint8
+		want     string
+	}{
+		{name: "full type name", settings: &WorkspaceSettings{Enums: map[string][]string{"Market.Status": statuses}}, value: 1, want: "Closed (1)"},
+		{name: "bare type name", settings: &WorkspaceSettings{Enums: map[string][]string{"Status": statuses}}, value: 2, want: "Settled (2)"},
+		{name: "first member", settings: &WorkspaceSettings{Enums: map[string][]string{"Status": statuses}}, value: 0, want: "Open (0)"},
+		{name: "out of range falls back to the number", settings: &WorkspaceSettings{Enums: map[string][]string{"Status": statuses}}, value: 3, want: "3"},
+		{name: "other enum configured", settings: &WorkspaceSettings{Enums: map[string][]string{"Side": {"Buy", "Sell"}}}, value: 1, want: "1"},
+		{name: "no settings", value: 1, want: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enums := loadMethodEnums(testWorkspace(t, tt.settings), deployments, "market", "status", 0)
+			if enums == nil {
+				t.Fatal("loadMethodEnums found no enum outputs for status()")
+			}
+			if got := enums.label(enums.types[0], tt.value); got != tt.want {
+				t.Errorf("label = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if enums := loadMethodEnums(testWorkspace(t, nil), deployments, "Market", "fee", 0); enums != nil {
+		t.Errorf("loadMethodEnums(fee) = %v, want nil for a method returning no enums", enums.types)
+	}
+}
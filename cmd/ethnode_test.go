@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// testChainID is the chain ID reported by fakeEthNode
+const testChainID = 31415926
+
+// testKey is a fixed sender key for transactions sent to a fakeEthNode
+const testKey = "b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291"
+
+// fakeEthNode is an in-memory Ethereum JSON-RPC node that accepts signed transactions and
+// mines them immediately with a successful receipt
+type fakeEthNode struct {
+	mu   sync.Mutex
+	sent []*ethtypes.Transaction
+	// replies holds the eth_call result for each 4-byte selector; other calls revert
+	replies map[string]hexutil.Bytes
+	// failSelectors makes gas estimation fail for calls with these 4-byte selectors
+	failSelectors map[string]bool
+}
+
+// fakeCallArgs is the part of an eth_call request the node looks at
+type fakeCallArgs struct {
+	Input hexutil.Bytes `json:"input"`
+}
+
+// newFakeEthNode serves a fakeEthNode over HTTP and returns it with its URL
+func newFakeEthNode(t *testing.T) (*fakeEthNode, string) {
+	t.Helper()
+
+	node := &fakeEthNode{replies: make(map[string]hexutil.Bytes), failSelectors: make(map[string]bool)}
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", node); err != nil {
+		t.Fatalf("failed to register eth service: %v", err)
+	}
+	if err := server.RegisterName("net", fakeNetService{}); err != nil {
+		t.Fatalf("failed to register net service: %v", err)
+	}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+	return node, httpServer.URL
+}
+
+// setCallReply makes eth_call return reply for calls to the method with signature sig
+func (n *fakeEthNode) setCallReply(sig string, reply []byte) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.replies[string(crypto.Keccak256([]byte(sig))[:4])] = reply
+}
+
+// failMethod makes gas estimation fail for calls to the method with signature sig
+func (n *fakeEthNode) failMethod(sig string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.failSelectors[string(crypto.Keccak256([]byte(sig))[:4])] = true
+}
+
+// transactions returns the transactions sent to the node so far
+func (n *fakeEthNode) transactions() []*ethtypes.Transaction {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]*ethtypes.Transaction(nil), n.sent...)
+}
+
+func (n *fakeEthNode) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(testChainID))
+}
+
+func (n *fakeEthNode) BlockNumber() hexutil.Uint64 {
+	return 1
+}
+
+func (n *fakeEthNode) GetTransactionCount(addr common.Address, block string) hexutil.Uint64 {
+	return 0
+}
+
+func (n *fakeEthNode) GasPrice() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(100))
+}
+
+func (n *fakeEthNode) EstimateGas(args fakeCallArgs) (hexutil.Uint64, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if len(args.Input) >= 4 && n.failSelectors[string(args.Input[:4])] {
+		return 0, errors.New("execution reverted")
+	}
+	return 100000, nil
+}
+
+func (n *fakeEthNode) GetBlockByNumber(number string, full bool) *ethtypes.Header {
+	return &ethtypes.Header{Number: big.NewInt(1), Difficulty: big.NewInt(0)}
+}
+
+func (n *fakeEthNode) GetCode(addr common.Address, block string) hexutil.Bytes {
+	return hexutil.Bytes{0x60, 0x80}
+}
+
+func (n *fakeEthNode) Call(args fakeCallArgs, block string) (hexutil.Bytes, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if len(args.Input) >= 4 {
+		if reply, ok := n.replies[string(args.Input[:4])]; ok {
+			return reply, nil
+		}
+	}
+	return nil, errors.New("execution reverted")
+}
+
+func (n *fakeEthNode) SendRawTransaction(data hexutil.Bytes) (common.Hash, error) {
+	tx := new(ethtypes.Transaction)
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return common.Hash{}, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sent = append(n.sent, tx)
+	return tx.Hash(), nil
+}
+
+func (n *fakeEthNode) GetTransactionReceipt(hash common.Hash) (*ethtypes.Receipt, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, tx := range n.sent {
+		if tx.Hash() == hash {
+			return &ethtypes.Receipt{
+				Status:      ethtypes.ReceiptStatusSuccessful,
+				TxHash:      hash,
+				GasUsed:     tx.Gas(),
+				Logs:        []*ethtypes.Log{},
+				BlockNumber: big.NewInt(1),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// fakeNetService answers net_version for fakeEthNode
+type fakeNetService struct{}
+
+func (fakeNetService) Version() string {
+	return big.NewInt(testChainID).String()
+}
+
+// testSender returns the key and address of the fixed test sender
+func testSender(t *testing.T) (string, common.Address) {
+	t.Helper()
+
+	key, err := crypto.HexToECDSA(testKey)
+	if err != nil {
+		t.Fatalf("invalid test key: %v", err)
+	}
+	return testKey, crypto.PubkeyToAddress(key.PublicKey)
+}
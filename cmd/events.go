@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/parthshah1/mpool-tx/config"
+	"github.com/urfave/cli/v2"
+)
+
+// maxLogBlockRange bounds each eth_getLogs request, since Lotus rejects large ranges
+const maxLogBlockRange = 2000
+
+// DecodedEvent is a contract log decoded against an ABI event
+type DecodedEvent struct {
+	Name        string
+	BlockNumber uint64
+	TxHash      common.Hash
+	Fields      map[string]interface{}
+	Order       []string
+	Hashed      map[string]bool // indexed dynamic fields, whose topic is a hash of the value
+}
+
+// isHashedTopic reports whether an indexed argument of type t is stored as the keccak hash of
+// its value, so its topic cannot be decoded back into the value
+func isHashedTopic(t abi.Type) bool {
+	switch t.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		return true
+	default:
+		return false
+	}
+}
+
+// findEvent looks up an ABI event by name or full signature (e.g. Transfer(address,address,uint256))
+func findEvent(parsedABI abi.ABI, event string) (*abi.Event, error) {
+	for _, ev := range parsedABI.Events {
+		if ev.Sig == event || ev.Name == event || ev.RawName == event {
+			ev := ev
+			return &ev, nil
+		}
+	}
+	return nil, fmt.Errorf("event %s not found in ABI", event)
+}
+
+// DecodeLog decodes a log into its event name and named fields using the ABI
+func DecodeLog(parsedABI abi.ABI, log types.Log) (*DecodedEvent, error) {
+	if len(log.Topics) == 0 {
+		return nil, fmt.Errorf("log has no topics")
+	}
+
+	ev, err := parsedABI.EventByID(log.Topics[0])
+	if err != nil {
+		return nil, fmt.Errorf("unknown event topic %s", log.Topics[0].Hex())
+	}
+
+	fields := make(map[string]interface{})
+	if len(log.Data) > 0 {
+		if err := parsedABI.UnpackIntoMap(fields, ev.Name, log.Data); err != nil {
+			return nil, fmt.Errorf("failed to decode %s data: %w", ev.Name, err)
+		}
+	}
+
+	// Hashed topics are kept as the raw hash; the rest are decoded
+	var indexed abi.Arguments
+	var topics []common.Hash
+	hashed := make(map[string]bool)
+	topicIndex := 1
+	for _, input := range ev.Inputs {
+		if !input.Indexed {
+			continue
+		}
+		if topicIndex >= len(log.Topics) {
+			return nil, fmt.Errorf("failed to decode %s topics: missing topic for %s", ev.Name, input.Name)
+		}
+		topic := log.Topics[topicIndex]
+		topicIndex++
+
+		if isHashedTopic(input.Type) {
+			fields[input.Name] = topic
+			hashed[input.Name] = true
+			continue
+		}
+		indexed = append(indexed, input)
+		topics = append(topics, topic)
+	}
+	if err := abi.ParseTopicsIntoMap(fields, indexed, topics); err != nil {
+		return nil, fmt.Errorf("failed to decode %s topics: %w", ev.Name, err)
+	}
+
+	order := make([]string, 0, len(ev.Inputs))
+	for _, input := range ev.Inputs {
+		order = append(order, input.Name)
+	}
+
+	return &DecodedEvent{
+		Name:        ev.Name,
+		BlockNumber: log.BlockNumber,
+		TxHash:      log.TxHash,
+		Fields:      fields,
+		Order:       order,
+		Hashed:      hashed,
+	}, nil
+}
+
+func (e *DecodedEvent) String() string {
+	parts := make([]string, 0, len(e.Order))
+	for _, name := range e.Order {
+		part := fmt.Sprintf("%s=%s", name, formatValue(e.Fields[name]))
+		if e.Hashed[name] {
+			part += " (indexed, hashed)"
+		}
+		parts = append(parts, part)
+	}
+	return fmt.Sprintf("%s(%s)", e.Name, strings.Join(parts, ", "))
+}
+
+func formatValue(v interface{}) string {
+	switch val := v.(type) {
+	case common.Address:
+		return val.Hex()
+	case common.Hash:
+		return val.Hex()
+	case *big.Int:
+		return val.String()
+	case []byte:
+		return fmt.Sprintf("0x%x", val)
+	case [32]byte:
+		return fmt.Sprintf("0x%x", val)
+	case string:
+		return fmt.Sprintf("%q", val)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+func contractEvents(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("usage: contract events <contract-name> [--event <signature>] [--from-block N] [--follow]")
+	}
+
+	contractName := c.Args().Get(0)
+	workspace := c.String("workspace")
+
+	deployments, err := loadDeployments(workspace)
+	if err != nil {
+		return err
+	}
+
+	record, err := findContractIgnoreCase(deployments, contractName)
+	if err != nil {
+		return err
+	}
+
+	parsedABI, err := loadABI(record.ABIPath)
+	if err != nil {
+		return err
+	}
+
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{common.HexToAddress(record.Address)},
+	}
+
+	if eventSig := c.String("event"); eventSig != "" {
+		ev, err := findEvent(parsedABI, eventSig)
+		if err != nil {
+			return err
+		}
+		query.Topics = [][]common.Hash{{ev.ID}}
+	} else {
+		var ids []common.Hash
+		names := make([]string, 0, len(parsedABI.Events))
+		for name := range parsedABI.Events {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			ids = append(ids, parsedABI.Events[name].ID)
+		}
+		if len(ids) == 0 {
+			return fmt.Errorf("ABI for %s declares no events", contractName)
+		}
+		query.Topics = [][]common.Hash{ids}
+	}
+
+	ctx := c.Context
+
+	cfg, err := loadWorkspaceConfig(ctx, workspace)
+	if err != nil {
+		return err
+	}
+
+	client, err := config.DialEthClient(cfg.RPC, cfg.Token)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer client.Close()
+
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get block number: %w", err)
+	}
+
+	fromBlock := head
+	if c.IsSet("from-block") {
+		fromBlock = c.Uint64("from-block")
+	}
+	toBlock := head
+	if c.IsSet("to-block") {
+		toBlock = c.Uint64("to-block")
+	}
+
+	follow := c.Bool("follow")
+	interval := c.Duration("interval")
+
+	fmt.Printf("Watching %s (%s) from block %d\n", contractName, record.Address, fromBlock)
+
+	for {
+		for start := fromBlock; start <= toBlock; start += maxLogBlockRange {
+			end := start + maxLogBlockRange - 1
+			if end > toBlock {
+				end = toBlock
+			}
+
+			query.FromBlock = new(big.Int).SetUint64(start)
+			query.ToBlock = new(big.Int).SetUint64(end)
+
+			logs, err := client.FilterLogs(ctx, query)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return fmt.Errorf("failed to filter logs for blocks %d-%d: %w", start, end, err)
+			}
+
+			for _, log := range logs {
+				decoded, err := DecodeLog(parsedABI, log)
+				if err != nil {
+					fmt.Printf("Block %d tx %s: %v\n", log.BlockNumber, log.TxHash.Hex(), err)
+					continue
+				}
+				fmt.Printf("Block %d tx %s: %s\n", decoded.BlockNumber, decoded.TxHash.Hex(), decoded)
+			}
+		}
+
+		if !follow {
+			return nil
+		}
+
+		fromBlock = toBlock + 1
+		for toBlock < fromBlock {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(interval):
+			}
+
+			toBlock, err = client.BlockNumber(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return fmt.Errorf("failed to get block number: %w", err)
+			}
+		}
+	}
+}
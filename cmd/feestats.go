@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+
+	filbig "github.com/filecoin-project/go-state-types/big"
+	"github.com/urfave/cli/v2"
+)
+
+// tipsetFees is the fee data of one tipset: the base fee its messages paid and their premiums
+type tipsetFees struct {
+	Height   int64
+	BaseFee  filbig.Int
+	Premiums []filbig.Int
+}
+
+// FeeDistribution summarizes a set of attoFIL amounts
+type FeeDistribution struct {
+	Min  filbig.Int `json:"min"`
+	Mean filbig.Int `json:"mean"`
+	P50  filbig.Int `json:"p50"`
+	P90  filbig.Int `json:"p90"`
+	Max  filbig.Int `json:"max"`
+}
+
+// FeeStats summarizes base fees and included gas premiums over a range of tipsets
+type FeeStats struct {
+	Tipsets    int              `json:"tipsets"`
+	FromHeight int64            `json:"from_height"`
+	ToHeight   int64            `json:"to_height"`
+	BaseFee    FeeDistribution  `json:"base_fee"`
+	OldestBase filbig.Int       `json:"base_fee_oldest"`
+	LatestBase filbig.Int       `json:"base_fee_latest"`
+	BaseChange float64          `json:"base_fee_change_pct"`
+	Messages   int              `json:"messages"`
+	Premium    *FeeDistribution `json:"gas_premium,omitempty"` // nil when no messages were included
+}
+
+// collectTipsetFees reads the fee data of the last count non-null tipsets, oldest first
+func collectTipsetFees(ctx context.Context, count int) ([]tipsetFees, error) {
+	node := clientt.GetAPI()
+
+	head, err := node.ChainHead(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain head: %w", err)
+	}
+
+	var fees []tipsetFees
+	ts := head
+	for len(fees) < count {
+		msgs, err := node.ChainGetMessagesInTipset(ctx, ts.Key())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get messages at height %d: %w", ts.Height(), err)
+		}
+
+		entry := tipsetFees{Height: int64(ts.Height()), BaseFee: ts.Blocks()[0].ParentBaseFee}
+		for _, msg := range msgs {
+			entry.Premiums = append(entry.Premiums, msg.Message.GasPremium)
+		}
+		fees = append(fees, entry)
+
+		if ts.Height() == 0 {
+			break
+		}
+		// A null round resolves to the nearest earlier tipset
+		parent := ts.Height() - 1
+		ts, err = node.ChainGetTipSetByHeight(ctx, parent, head.Key())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tipset at height %d: %w", parent, err)
+		}
+	}
+
+	for i, j := 0, len(fees)-1; i < j; i, j = i+1, j-1 {
+		fees[i], fees[j] = fees[j], fees[i]
+	}
+	return fees, nil
+}
+
+// computeFeeStats summarizes tipsets, which must be non-empty and ordered oldest first
+func computeFeeStats(tipsets []tipsetFees) FeeStats {
+	baseFees := make([]filbig.Int, len(tipsets))
+	var premiums []filbig.Int
+	for i, ts := range tipsets {
+		baseFees[i] = ts.BaseFee
+		premiums = append(premiums, ts.Premiums...)
+	}
+
+	oldest := tipsets[0].BaseFee
+	latest := tipsets[len(tipsets)-1].BaseFee
+
+	stats := FeeStats{
+		Tipsets:    len(tipsets),
+		FromHeight: tipsets[0].Height,
+		ToHeight:   tipsets[len(tipsets)-1].Height,
+		BaseFee:    computeFeeDistribution(baseFees),
+		OldestBase: oldest,
+		LatestBase: latest,
+		Messages:   len(premiums),
+	}
+	if oldest.Sign() > 0 {
+		change := new(big.Float).SetInt(filbig.Sub(latest, oldest).Int)
+		change.Quo(change, new(big.Float).SetInt(oldest.Int))
+		stats.BaseChange, _ = change.Mul(change, big.NewFloat(100)).Float64()
+	}
+	if len(premiums) > 0 {
+		dist := computeFeeDistribution(premiums)
+		stats.Premium = &dist
+	}
+	return stats
+}
+
+// computeFeeDistribution returns min/mean/max and nearest-rank percentiles of amounts, which
+// must be non-empty
+func computeFeeDistribution(amounts []filbig.Int) FeeDistribution {
+	sorted := append([]filbig.Int(nil), amounts...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].LessThan(sorted[j]) })
+
+	sum := filbig.Zero()
+	for _, a := range sorted {
+		sum = filbig.Add(sum, a)
+	}
+
+	percentile := func(p int) filbig.Int {
+		rank := (p*len(sorted) + 99) / 100
+		if rank < 1 {
+			rank = 1
+		}
+		return sorted[rank-1]
+	}
+
+	return FeeDistribution{
+		Min:  sorted[0],
+		Mean: filbig.Div(sum, filbig.NewInt(int64(len(sorted)))),
+		P50:  percentile(50),
+		P90:  percentile(90),
+		Max:  sorted[len(sorted)-1],
+	}
+}
+
+func mempoolGasStats(c *cli.Context) error {
+	blocks := c.Int("blocks")
+	if blocks <= 0 {
+		return fmt.Errorf("--blocks must be positive")
+	}
+
+	tipsets, err := collectTipsetFees(c.Context, blocks)
+	if err != nil {
+		return err
+	}
+	stats := computeFeeStats(tipsets)
+
+	if jsonOutput(c) {
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal gas stats: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printFeeStats(stats)
+	return nil
+}
+
+func printFeeStats(stats FeeStats) {
+	fmt.Printf("Tipsets %d-%d (%d tipsets, %d messages), amounts in attoFIL\n", stats.FromHeight, stats.ToHeight, stats.Tipsets, stats.Messages)
+	fmt.Printf("Base fee:    %s -> %s (%+.1f%%)\n", stats.OldestBase, stats.LatestBase, stats.BaseChange)
+	printFeeDistribution(stats.BaseFee)
+	if stats.Premium == nil {
+		fmt.Println("Gas premium: no messages included")
+		return
+	}
+	fmt.Println("Gas premium:")
+	printFeeDistribution(*stats.Premium)
+}
+
+func printFeeDistribution(d FeeDistribution) {
+	fmt.Printf("  min %s  mean %s  p50 %s  p90 %s  max %s\n", d.Min, d.Mean, d.P50, d.P90, d.Max)
+}
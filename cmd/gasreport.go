@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/parthshah1/mpool-tx/config"
+)
+
+// contractGas collects the transactions sent for one contract during deploy-local:
+// its deployment (when the hash is known) and its post-deployment actions
+type contractGas struct {
+	Name   string
+	Hashes []common.Hash
+}
+
+// gasTotals is the gas used and fees paid by a set of transactions
+type gasTotals struct {
+	GasUsed uint64
+	Cost    *big.Int
+	Missing int // transactions without a receipt
+}
+
+func (t *gasTotals) add(other gasTotals) {
+	t.GasUsed += other.GasUsed
+	t.Cost = new(big.Int).Add(t.Cost, other.Cost)
+	t.Missing += other.Missing
+}
+
+// printGasSummary fetches the receipts of every recorded transaction and prints the gas and
+// FIL spent per contract and in total. Receipts that cannot be fetched are counted as missing.
+func printGasSummary(ctx context.Context, rpcURL string, usage []contractGas) {
+	if len(usage) == 0 {
+		return
+	}
+
+	client, err := config.DialEthClient(rpcURL, cfg.Token)
+	if err != nil {
+		fmt.Printf("Warning: failed to connect for gas summary: %v\n", err)
+		return
+	}
+	defer client.Close()
+
+	fmt.Println("====== Gas summary ======")
+	total := gasTotals{Cost: new(big.Int)}
+	for _, u := range usage {
+		totals := gasTotals{Cost: new(big.Int)}
+		for _, hash := range u.Hashes {
+			receipt, err := client.TransactionReceipt(ctx, hash)
+			if err != nil || receipt == nil {
+				totals.Missing++
+				continue
+			}
+			totals.add(receiptGas(receipt.GasUsed, receipt.EffectiveGasPrice))
+		}
+		total.add(totals)
+
+		fmt.Printf("%s: %d gas, %s (%d tx)", u.Name, totals.GasUsed, types.FIL(types.BigInt{Int: totals.Cost}), len(u.Hashes))
+		if totals.Missing > 0 {
+			fmt.Printf(", %d receipt(s) unavailable", totals.Missing)
+		}
+		if len(u.Hashes) == 0 {
+			fmt.Print(", deployment tx hash unknown")
+		}
+		fmt.Println()
+	}
+
+	fmt.Printf("Total: %d gas, %s\n", total.GasUsed, types.FIL(types.BigInt{Int: total.Cost}))
+	if total.Missing > 0 {
+		fmt.Printf("Note: %d transaction(s) had no receipt and are excluded from the total\n", total.Missing)
+	}
+}
+
+// receiptGas returns the gas and cost of a single receipt
+func receiptGas(gasUsed uint64, effectiveGasPrice *big.Int) gasTotals {
+	cost := new(big.Int)
+	if effectiveGasPrice != nil {
+		cost.Mul(new(big.Int).SetUint64(gasUsed), effectiveGasPrice)
+	}
+	return gasTotals{GasUsed: gasUsed, Cost: cost}
+}
+
+// gasStats summarizes the gas used by repeated transactions
+type gasStats struct {
+	Min, Max, Mean, P50, P95 uint64
+}
+
+// computeGasStats returns min/max/mean and nearest-rank percentiles of samples, which must be non-empty
+func computeGasStats(samples []uint64) gasStats {
+	sorted := append([]uint64(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum uint64
+	for _, s := range sorted {
+		sum += s
+	}
+
+	percentile := func(p int) uint64 {
+		rank := (p*len(sorted) + 99) / 100
+		if rank < 1 {
+			rank = 1
+		}
+		return sorted[rank-1]
+	}
+
+	return gasStats{
+		Min:  sorted[0],
+		Max:  sorted[len(sorted)-1],
+		Mean: sum / uint64(len(sorted)),
+		P50:  percentile(50),
+		P95:  percentile(95),
+	}
+}
@@ -12,9 +12,11 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/chain/types/ethtypes"
+	"github.com/parthshah1/mpool-tx/config"
 )
 
 const DefaultKeystorePassword = "filwizard"
@@ -42,20 +44,42 @@ type ContractProject struct {
 }
 
 type DeployedContract struct {
-	Name               string              `json:"name"`
-	Address            ethtypes.EthAddress `json:"address"`
-	DeployerAddress    ethtypes.EthAddress `json:"deployer_address"`
-	DeployerPrivateKey string              `json:"deployer_private_key"`
-	TransactionHash    ethtypes.EthHash    `json:"txhash"`
-	AbiPath            string              `json:"abi_path"`
-	BindingsPath       string              `json:"bindings_path"`
+	Name               string               `json:"name"`
+	Address            ethtypes.EthAddress  `json:"address"`
+	DeployerAddress    ethtypes.EthAddress  `json:"deployer_address"`
+	DeployerPrivateKey string               `json:"deployer_private_key"`
+	TransactionHash    ethtypes.EthHash     `json:"txhash"`
+	AbiPath            string               `json:"abi_path"`
+	BindingsPath       string               `json:"bindings_path"`
+	Implementation     *ethtypes.EthAddress `json:"implementation_address,omitempty"`
+	ConfigHash         string               `json:"config_hash,omitempty"`
+}
+
+// MarshalJSON writes the contract, deployer, and implementation addresses in EIP-55 checksummed form
+func (d DeployedContract) MarshalJSON() ([]byte, error) {
+	type plain DeployedContract
+	out := struct {
+		plain
+		Address         string `json:"address"`
+		DeployerAddress string `json:"deployer_address"`
+		Implementation  string `json:"implementation_address,omitempty"`
+	}{
+		plain:           plain(d),
+		Address:         config.ChecksumAddress(d.Address.String()),
+		DeployerAddress: config.ChecksumAddress(d.DeployerAddress.String()),
+	}
+	if d.Implementation != nil {
+		out.Implementation = config.ChecksumAddress(d.Implementation.String())
+	}
+	return json.Marshal(out)
 }
 
 // AccountInfo holds account details for JSON serialization
 type AccountInfo struct {
-	Address    string `json:"address"`
+	Address    string `json:"address"` // delegated (f410) address
 	EthAddress string `json:"ethAddress"`
 	PrivateKey string `json:"privateKey"`
+	IDAddress  string `json:"idAddress,omitempty"` // f0 address, once the account exists on chain
 }
 
 // AccountsFile holds the structure of accounts.json
@@ -70,6 +94,8 @@ type ContractManager struct {
 	keystorePath     string
 	keystorePassword string
 	rpcURL           string
+	bindingsDir      string
+	bindingsPackage  string
 }
 
 func NewContractManager(workspaceDir, rpcURL string) *ContractManager {
@@ -100,6 +126,28 @@ func (cm *ContractManager) SetDeployerKey(privateKey string) {
 	fmt.Printf("Created ETH keystore at %s (password: %s)\n", keystoreFile, cm.keystorePassword)
 }
 
+// SetDeploymentsFile overrides the deployments file, e.g. for a network-specific deployments.<network>.json
+func (cm *ContractManager) SetDeploymentsFile(path string) {
+	absPath, _ := filepath.Abs(path)
+	cm.deploymentsFile = absPath
+}
+
+// SetBindingsOutput overrides where Go bindings are written and their package name.
+// Empty values keep the defaults: <workspace>/contracts and package "contracts".
+func (cm *ContractManager) SetBindingsOutput(dir, pkg string) {
+	cm.bindingsDir = dir
+	cm.bindingsPackage = pkg
+}
+
+// ethAddressFromPrivateKey derives the Ethereum address for a hex-encoded secp256k1 private key
+func ethAddressFromPrivateKey(privateKey string) (ethtypes.EthAddress, error) {
+	key, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return ethtypes.EthAddress{}, err
+	}
+	return ethtypes.CastEthAddress(crypto.PubkeyToAddress(key.PublicKey).Bytes())
+}
+
 func (cm *ContractManager) GetDeployerKey() string {
 	return cm.deployerKey
 }
@@ -348,14 +396,14 @@ func (cm *ContractManager) CompileFoundryProject(project *ContractProject) error
 	return nil
 }
 
-func (cm *ContractManager) CreateDeployerAccount() (string, ethtypes.EthAddress, error) {
+func (cm *ContractManager) CreateDeployerAccount(ctx context.Context) (string, ethtypes.EthAddress, error) {
 	key, ethAddr, filAddr, err := NewAccount()
 	if err != nil {
 		return "", ethtypes.EthAddress{}, fmt.Errorf("failed to create account: %w", err)
 	}
 
 	fundAmount := types.FromFil(10)
-	_, err = FundWallet(context.Background(), filAddr, fundAmount, true)
+	_, err = FundWallet(ctx, filAddr, fundAmount, true)
 	if err != nil {
 		return "", ethtypes.EthAddress{}, fmt.Errorf("failed to fund deployer account: %w", err)
 	}
@@ -547,33 +595,28 @@ func (cm *ContractManager) extractABIWithForgeInspect(project *ContractProject,
 }
 
 func (cm *ContractManager) generateBindings(contractName, abiPath string) (string, error) {
-	contractsDir := filepath.Join(cm.workspaceDir, "contracts")
-	bindingsPath := filepath.Join(contractsDir, fmt.Sprintf("%s.go", strings.ToLower(contractName)))
-
-	cmd := exec.Command("abigen",
-		"--abi", abiPath,
-		"--pkg", "contracts",
-		"--type", contractName,
-		"--out", bindingsPath)
-
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return "", fmt.Errorf("failed to generate Go bindings: %w, output: %s", err, string(output))
+	outputDir := cm.bindingsDir
+	if outputDir == "" {
+		outputDir = filepath.Join(cm.workspaceDir, "contracts")
 	}
-
-	return bindingsPath, nil
+	return runAbigen(contractName, abiPath, "", outputDir, cm.bindingsPackage)
 }
 
 func (cm *ContractManager) parseForgeCreateOutput(output string, project *ContractProject, contractPath string) (*DeployedContract, error) {
 	lines := strings.Split(output, "\n")
-	var contractAddr string
+	var contractAddr, txHash string
 
 	for _, line := range lines {
 		if strings.Contains(line, "Deployed to:") {
 			parts := strings.Split(line, "Deployed to:")
 			if len(parts) > 1 {
 				contractAddr = strings.TrimSpace(parts[1])
-				break
+			}
+		}
+		if strings.Contains(line, "Transaction hash:") {
+			parts := strings.Split(line, "Transaction hash:")
+			if len(parts) > 1 {
+				txHash = strings.TrimSpace(parts[1])
 			}
 		}
 	}
@@ -587,15 +630,17 @@ func (cm *ContractManager) parseForgeCreateOutput(output string, project *Contra
 		return nil, fmt.Errorf("failed to parse contract address: %w", err)
 	}
 
-	cmd := exec.Command("cast", "wallet", "address", "--private-key", cm.deployerKey)
-	deployerOutput, err := cmd.CombinedOutput()
+	deployerAddr, err := ethAddressFromPrivateKey(cm.deployerKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get deployer address: %w", err)
 	}
 
-	deployerAddr, err := ethtypes.ParseEthAddress(strings.TrimSpace(string(deployerOutput)))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse deployer address: %w", err)
+	// The hash is only used for reporting, so an unparseable one is left empty
+	var hash ethtypes.EthHash
+	if txHash != "" {
+		if parsed, err := ethtypes.ParseEthHash(txHash); err == nil {
+			hash = parsed
+		}
 	}
 
 	return &DeployedContract{
@@ -603,7 +648,7 @@ func (cm *ContractManager) parseForgeCreateOutput(output string, project *Contra
 		Address:            ethAddr,
 		DeployerAddress:    deployerAddr,
 		DeployerPrivateKey: cm.deployerKey,
-		TransactionHash:    ethtypes.EthHash{},
+		TransactionHash:    hash,
 	}, nil
 }
 
@@ -776,7 +821,7 @@ func (cm *ContractManager) saveDeployerAccount(contract *DeployedContract) error
 	if _, exists := accounts.Accounts["deployer"]; !exists {
 		accounts.Accounts["deployer"] = AccountInfo{
 			Address:    filAddr.String(),
-			EthAddress: ethAddrStr,
+			EthAddress: config.ChecksumAddress(ethAddrStr),
 			PrivateKey: contract.DeployerPrivateKey,
 		}
 
@@ -860,6 +905,37 @@ func (cm *ContractManager) GetDeployment(contractName string) (*DeployedContract
 	return nil, fmt.Errorf("deployment not found for contract: %s", contractName)
 }
 
+// SetImplementationAddress records a new implementation address on an existing proxy deployment
+func (cm *ContractManager) SetImplementationAddress(proxyName string, implementation ethtypes.EthAddress) error {
+	deployments, err := cm.LoadDeployments()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, d := range deployments {
+		if strings.EqualFold(d.Name, proxyName) {
+			impl := implementation
+			d.Implementation = &impl
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("deployment not found for contract: %s", proxyName)
+	}
+
+	data, err := json.MarshalIndent(deployments, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal deployments: %w", err)
+	}
+
+	if err := os.WriteFile(cm.deploymentsFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write deployments file: %w", err)
+	}
+
+	return nil
+}
+
 func (cm *ContractManager) EnsureCloneCommandsExecuted(project *ContractProject) error {
 	if len(project.CloneCommands) == 0 {
 		return nil
@@ -927,24 +1003,83 @@ func (cm *ContractManager) CleanupProject(project *ContractProject) error {
 	return nil
 }
 
-func (cm *ContractManager) CleanupWorkspace() error {
+// CleanupTargets lists the temporary project directories CleanupWorkspace would remove
+func (cm *ContractManager) CleanupTargets() ([]string, error) {
 	entries, err := os.ReadDir(cm.workspaceDir)
 	if err != nil {
-		return fmt.Errorf("failed to read workspace directory: %w", err)
+		return nil, fmt.Errorf("failed to read workspace directory: %w", err)
 	}
 
+	var targets []string
 	for _, entry := range entries {
 		if entry.IsDir() && strings.HasPrefix(entry.Name(), "project_") {
-			projectPath := filepath.Join(cm.workspaceDir, entry.Name())
-			if err := os.RemoveAll(projectPath); err != nil {
-				return fmt.Errorf("failed to remove project directory %s: %w", projectPath, err)
-			}
+			targets = append(targets, entry.Name())
+		}
+	}
+	return targets, nil
+}
+
+func (cm *ContractManager) CleanupWorkspace() error {
+	targets, err := cm.CleanupTargets()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range targets {
+		projectPath := filepath.Join(cm.workspaceDir, name)
+		if err := os.RemoveAll(projectPath); err != nil {
+			return fmt.Errorf("failed to remove project directory %s: %w", projectPath, err)
 		}
 	}
 
 	return nil
 }
 
+// ResetTargets lists the workspace entries ResetWorkspace would remove.
+// Accounts include the keystore and deployer env script; artifacts are the contracts directory.
+func (cm *ContractManager) ResetTargets(keepDeployments, keepAccounts, keepArtifacts bool) ([]string, error) {
+	entries, err := os.ReadDir(cm.workspaceDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workspace directory: %w", err)
+	}
+
+	keep := map[string]bool{
+		"deployments.json":     keepDeployments,
+		"post-deployment.json": keepDeployments,
+		"accounts.json":        keepAccounts,
+		"keystore":             keepAccounts,
+		"deployer-env.sh":      keepAccounts,
+		"contracts":            keepArtifacts,
+	}
+
+	var targets []string
+	for _, entry := range entries {
+		if !keep[entry.Name()] {
+			targets = append(targets, entry.Name())
+		}
+	}
+	return targets, nil
+}
+
+// ResetWorkspace removes everything in the workspace except the state files selected for preservation
+func (cm *ContractManager) ResetWorkspace(keepDeployments, keepAccounts, keepArtifacts bool) ([]string, error) {
+	targets, err := cm.ResetTargets(keepDeployments, keepAccounts, keepArtifacts)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for _, name := range targets {
+		path := filepath.Join(cm.workspaceDir, name)
+		if err := os.RemoveAll(path); err != nil {
+			return removed, fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		removed = append(removed, name)
+	}
+
+	return removed, nil
+}
+
 // ImportScriptOutputToDeployments parses arbitrary script output and imports contract addresses
 // into the workspace deployments.json. The expected file contains lines with '<Name>: <address>'
 // or any line containing a 0x-prefixed address. All contracts found in the output will be
@@ -964,11 +1099,8 @@ func (cm *ContractManager) ImportScriptOutputToDeployments(contractsConfigPath,
 	// Get deployer address once if we have the key
 	var deployerAddr ethtypes.EthAddress
 	if cm.deployerKey != "" {
-		cmd := exec.Command("cast", "wallet", "address", "--private-key", cm.deployerKey)
-		if deployerOutput, err := cmd.CombinedOutput(); err == nil {
-			if addr, err := ethtypes.ParseEthAddress(strings.TrimSpace(string(deployerOutput))); err == nil {
-				deployerAddr = addr
-			}
+		if addr, err := ethAddressFromPrivateKey(cm.deployerKey); err == nil {
+			deployerAddr = addr
 		}
 	}
 
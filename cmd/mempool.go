@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/urfave/cli/v2"
+)
+
+// rbfPremiumNum/rbfPremiumDenom is the minimum gas premium increase (+25%) the
+// message pool requires to replace a pending message with the same nonce
+const (
+	rbfPremiumNum   = 125
+	rbfPremiumDenom = 100
+)
+
+// SenderStats summarizes the pending messages of a single sender
+type SenderStats struct {
+	Count    int    `json:"count"`
+	MinNonce uint64 `json:"min_nonce"`
+	MaxNonce uint64 `json:"max_nonce"`
+}
+
+// MempoolStatus is a snapshot of the node's pending messages
+type MempoolStatus struct {
+	Time    time.Time               `json:"time"`
+	Pending int                     `json:"pending"`
+	Senders map[string]*SenderStats `json:"senders"`
+}
+
+// GetMempoolStatus returns the pending message count and per-sender stats
+func GetMempoolStatus(ctx context.Context) (*MempoolStatus, error) {
+	pending, err := clientt.GetAPI().MpoolPending(ctx, types.EmptyTSK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending messages: %w", err)
+	}
+
+	status := &MempoolStatus{
+		Time:    time.Now(),
+		Pending: len(pending),
+		Senders: make(map[string]*SenderStats),
+	}
+
+	for _, smsg := range pending {
+		from := smsg.Message.From.String()
+		nonce := smsg.Message.Nonce
+
+		stats, ok := status.Senders[from]
+		if !ok {
+			status.Senders[from] = &SenderStats{Count: 1, MinNonce: nonce, MaxNonce: nonce}
+			continue
+		}
+		stats.Count++
+		if nonce < stats.MinNonce {
+			stats.MinNonce = nonce
+		}
+		if nonce > stats.MaxNonce {
+			stats.MaxNonce = nonce
+		}
+	}
+
+	return status, nil
+}
+
+var MempoolCmd = &cli.Command{
+	Name:  "mempool",
+	Usage: "Mempool inspection",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "status",
+			Usage:  "Show pending message count and per-sender stats",
+			Action: mempoolStatus,
+		},
+		{
+			Name:  "watch",
+			Usage: "Continuously report mempool size over time",
+			Flags: []cli.Flag{
+				&cli.DurationFlag{
+					Name:  "interval",
+					Value: 2 * time.Second,
+					Usage: "Polling interval",
+				},
+				&cli.DurationFlag{
+					Name:  "duration",
+					Value: time.Minute,
+					Usage: "How long to watch (0 = until interrupted)",
+				},
+				&cli.BoolFlag{
+					Name:  "json",
+					Usage: "Stream one JSON sample per line",
+				},
+			},
+			Action: mempoolWatch,
+		},
+		{
+			Name:  "cancel",
+			Usage: "Replace a stuck pending message with a 0-value self-send at the same nonce",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "from",
+					Usage:    "Sender address of the stuck message (must be in the node wallet)",
+					Required: true,
+				},
+				&cli.Uint64Flag{
+					Name:     "nonce",
+					Usage:    "Nonce of the stuck message",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  "premium",
+					Usage: "Gas premium in attoFIL (default: the minimum replace-by-fee bump over the stuck message)",
+				},
+				&cli.BoolFlag{
+					Name:  "wait",
+					Usage: "Wait for the replacement to be confirmed (see --confirmations)",
+				},
+			},
+			Action: mempoolCancel,
+		},
+		{
+			Name:  "gas-stats",
+			Usage: "Summarize base fees and included gas premiums over recent tipsets",
+			Flags: []cli.Flag{
+				&cli.IntFlag{
+					Name:  "blocks",
+					Value: 20,
+					Usage: "Number of recent tipsets to read",
+				},
+				&cli.BoolFlag{
+					Name:  "json",
+					Usage: "Output the summary as JSON",
+				},
+			},
+			Action: mempoolGasStats,
+		},
+	},
+}
+
+func mempoolStatus(c *cli.Context) error {
+	status, err := GetMempoolStatus(c.Context)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput(c) {
+		return printJSON(status)
+	}
+
+	printMempoolStatus(status)
+	return nil
+}
+
+func mempoolWatch(c *cli.Context) error {
+	interval := c.Duration("interval")
+	duration := c.Duration("duration")
+	asJSON := jsonOutput(c)
+
+	if interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	ctx := c.Context
+
+	if duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, duration)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	samples := 0
+loop:
+	for {
+		status, err := GetMempoolStatus(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return err
+		}
+		samples++
+
+		if asJSON {
+			data, err := json.Marshal(status)
+			if err != nil {
+				return fmt.Errorf("failed to marshal sample: %w", err)
+			}
+			fmt.Println(string(data))
+		} else {
+			printMempoolStatus(status)
+		}
+
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-ticker.C:
+		}
+	}
+
+	if !asJSON {
+		fmt.Printf("Collected %d sample(s)\n", samples)
+	}
+	return nil
+}
+
+func mempoolCancel(c *cli.Context) error {
+	ctx := c.Context
+	api := clientt.GetAPI()
+
+	from, err := address.NewFromString(c.String("from"))
+	if err != nil {
+		return fmt.Errorf("invalid --from address: %w", err)
+	}
+	nonce := c.Uint64("nonce")
+
+	stuck, err := findPendingMessage(ctx, from, nonce)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Stuck message %s: to %s, value %s, premium %s\n", stuck.Cid(), stuck.Message.To, types.FIL(stuck.Message.Value), stuck.Message.GasPremium)
+
+	msg := &types.Message{
+		From:  from,
+		To:    from,
+		Value: big.Zero(),
+		Nonce: nonce,
+	}
+	estimated, err := api.GasEstimateMessageGas(ctx, msg, nil, types.EmptyTSK)
+	if err != nil {
+		return fmt.Errorf("failed to estimate gas: %w", err)
+	}
+
+	minPremium := replacementPremium(stuck.Message.GasPremium)
+	premium := big.Max(estimated.GasPremium, minPremium)
+	if s := c.String("premium"); s != "" {
+		premium, err = big.FromString(s)
+		if err != nil {
+			return fmt.Errorf("invalid --premium: %w", err)
+		}
+		if premium.LessThan(minPremium) {
+			return fmt.Errorf("--premium %s is below the minimum replacement premium %s", premium, minPremium)
+		}
+	}
+
+	cancelMsg := buildCancelMessage(from, nonce, estimated, &stuck.Message, premium)
+
+	signed, err := api.WalletSignMessage(ctx, from, cancelMsg)
+	if err != nil {
+		return fmt.Errorf("failed to sign replacement message: %w", err)
+	}
+
+	cid, err := api.MpoolPush(ctx, signed)
+	if err != nil {
+		return fmt.Errorf("failed to push replacement message: %w", err)
+	}
+
+	fmt.Printf("Replaced nonce %d from %s (premium %s -> %s)\n", nonce, from, stuck.Message.GasPremium, cancelMsg.GasPremium)
+	fmt.Printf("Replacement CID: %s\n", cid)
+
+	if c.Bool("wait") {
+		lookup, err := waitForMessage(ctx, api, cid)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Replacement confirmed at epoch %d\n", lookup.Height)
+	}
+	return nil
+}
+
+// findPendingMessage returns the pending message from sender at nonce, matching either address form
+func findPendingMessage(ctx context.Context, from address.Address, nonce uint64) (*types.SignedMessage, error) {
+	api := clientt.GetAPI()
+
+	fromID, err := api.StateLookupID(ctx, from, types.EmptyTSK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", from, err)
+	}
+
+	pending, err := api.MpoolPending(ctx, types.EmptyTSK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending messages: %w", err)
+	}
+
+	for _, smsg := range pending {
+		if smsg.Message.Nonce != nonce {
+			continue
+		}
+		if smsg.Message.From == from || smsg.Message.From == fromID {
+			return smsg, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no pending message from %s with nonce %d (already mined or never sent)", from, nonce)
+}
+
+// replacementPremium returns the lowest premium the mempool accepts to replace a message paying old
+func replacementPremium(old big.Int) big.Int {
+	bumped := big.Div(big.Mul(old, big.NewInt(rbfPremiumNum)), big.NewInt(rbfPremiumDenom))
+	return big.Add(bumped, big.NewInt(1))
+}
+
+// buildCancelMessage creates a 0-value self-send at nonce paying premium, with a fee cap
+// high enough to cover both the estimate and the stuck message's fee cap
+func buildCancelMessage(from address.Address, nonce uint64, estimated, stuck *types.Message, premium big.Int) *types.Message {
+	feeCap := big.Max(estimated.GasFeeCap, stuck.GasFeeCap)
+	feeCap = big.Max(feeCap, premium)
+
+	return &types.Message{
+		From:       from,
+		To:         from,
+		Value:      big.Zero(),
+		Nonce:      nonce,
+		Method:     0,
+		GasLimit:   estimated.GasLimit,
+		GasFeeCap:  feeCap,
+		GasPremium: premium,
+	}
+}
+
+func printMempoolStatus(status *MempoolStatus) {
+	fmt.Printf("[%s] Pending: %d messages from %d sender(s)\n", status.Time.Format("15:04:05"), status.Pending, len(status.Senders))
+
+	senders := make([]string, 0, len(status.Senders))
+	for from := range status.Senders {
+		senders = append(senders, from)
+	}
+	sort.Strings(senders)
+
+	for _, from := range senders {
+		stats := status.Senders[from]
+		fmt.Printf("  %s: %d pending (nonces %d-%d)\n", from, stats.Count, stats.MinNonce, stats.MaxNonce)
+	}
+}
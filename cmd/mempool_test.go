@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/lotus/chain/types"
+)
+
+func TestBuildCancelMessage(t *testing.T) {
+	from, err := address.NewIDAddress(1001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, err := address.NewIDAddress(1002)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		stuckPremium int64
+		stuckFeeCap  int64
+		estFeeCap    int64
+	}{
+		{name: "estimate covers the stuck fee cap", stuckPremium: 100000, stuckFeeCap: 200000, estFeeCap: 500000},
+		{name: "stuck fee cap above the estimate", stuckPremium: 100000, stuckFeeCap: 900000, estFeeCap: 500000},
+		{name: "premium above both fee caps", stuckPremium: 800000, stuckFeeCap: 800000, estFeeCap: 500000},
+		{name: "premium rounds up", stuckPremium: 3, stuckFeeCap: 100, estFeeCap: 100},
+		{name: "zero premium", stuckPremium: 0, stuckFeeCap: 100, estFeeCap: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const nonce = 42
+
+			stuck := &types.Message{
+				From:       from,
+				To:         to,
+				Nonce:      nonce,
+				Value:      types.NewInt(1000),
+				GasPremium: big.NewInt(tt.stuckPremium),
+				GasFeeCap:  big.NewInt(tt.stuckFeeCap),
+			}
+			estimated := &types.Message{
+				GasLimit:   1500000,
+				GasPremium: big.NewInt(1),
+				GasFeeCap:  big.NewInt(tt.estFeeCap),
+			}
+
+			premium := replacementPremium(stuck.GasPremium)
+			msg := buildCancelMessage(from, nonce, estimated, stuck, premium)
+
+			if msg.Nonce != nonce {
+				t.Errorf("nonce = %d, want %d", msg.Nonce, nonce)
+			}
+			if !msg.Value.IsZero() {
+				t.Errorf("value = %s, want 0", msg.Value)
+			}
+			if msg.From != from || msg.To != from {
+				t.Errorf("message %s -> %s, want a self-send from %s", msg.From, msg.To, from)
+			}
+			if msg.Method != 0 {
+				t.Errorf("method = %d, want 0", msg.Method)
+			}
+			if msg.GasLimit != estimated.GasLimit {
+				t.Errorf("gas limit = %d, want %d", msg.GasLimit, estimated.GasLimit)
+			}
+
+			// The mempool only replaces a message whose premium is bumped by at least 25%
+			bumped := big.Mul(msg.GasPremium, big.NewInt(100))
+			if bumped.LessThan(big.Mul(stuck.GasPremium, big.NewInt(125))) {
+				t.Errorf("premium = %s, want at least 125%% of %s", msg.GasPremium, stuck.GasPremium)
+			}
+			if !msg.GasPremium.GreaterThan(stuck.GasPremium) {
+				t.Errorf("premium = %s, want above the stuck premium %s", msg.GasPremium, stuck.GasPremium)
+			}
+
+			for _, floor := range []big.Int{msg.GasPremium, stuck.GasFeeCap, estimated.GasFeeCap} {
+				if msg.GasFeeCap.LessThan(floor) {
+					t.Errorf("fee cap = %s, want at least %s", msg.GasFeeCap, floor)
+				}
+			}
+		})
+	}
+}
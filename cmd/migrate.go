@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/parthshah1/mpool-tx/config"
+	"github.com/urfave/cli/v2"
+)
+
+// migrateWorkspace upgrades a workspace's deployments.json and accounts.json to the current
+// schema. Each changed file is backed up to <file>.bak and replaced atomically.
+func migrateWorkspace(c *cli.Context) error {
+	workspace := c.String("workspace")
+	dryRun := c.Bool("dry-run")
+
+	deploymentsPath := filepath.Join(workspace, "deployments.json")
+	accountsPath := filepath.Join(workspace, "accounts.json")
+
+	var migrated int
+	for _, step := range []struct {
+		path    string
+		migrate func([]byte) ([]byte, []string, error)
+	}{
+		{deploymentsPath, migrateDeploymentsJSON},
+		{accountsPath, migrateAccountsJSON},
+	} {
+		data, err := os.ReadFile(step.path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", step.path, err)
+		}
+
+		out, changes, err := step.migrate(data)
+		if err != nil {
+			return fmt.Errorf("failed to migrate %s: %w", step.path, err)
+		}
+		if len(changes) == 0 {
+			fmt.Printf("%s: up to date\n", step.path)
+			continue
+		}
+
+		fmt.Printf("%s:\n", step.path)
+		for _, change := range changes {
+			fmt.Printf("  %s\n", change)
+		}
+		if dryRun {
+			continue
+		}
+
+		backup := step.path + ".bak"
+		if err := writeFileAtomic(backup, data, 0600); err != nil {
+			return fmt.Errorf("failed to back up %s: %w", step.path, err)
+		}
+		if err := writeFileAtomic(step.path, out, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", step.path, err)
+		}
+		fmt.Printf("  backup saved to %s\n", backup)
+		migrated++
+	}
+
+	if dryRun {
+		fmt.Println("Dry run: no files were changed")
+	} else if migrated == 0 {
+		fmt.Println("Nothing to migrate")
+	}
+	return nil
+}
+
+// migrateDeploymentsJSON checksums addresses, fills in deployer addresses from deployer keys,
+// and drops repeated records of the same contract at the same address, keeping the latest
+func migrateDeploymentsJSON(data []byte) ([]byte, []string, error) {
+	var records []config.DeploymentRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse deployments: %w", err)
+	}
+
+	var changes []string
+	for i := range records {
+		r := &records[i]
+
+		if r.DeployerAddress == "" && r.DeployerPrivateKey != "" {
+			if key, err := parsePrivateKey(r.DeployerPrivateKey); err == nil {
+				r.DeployerAddress = crypto.PubkeyToAddress(key.PublicKey).Hex()
+				changes = append(changes, fmt.Sprintf("%s: filled deployer_address from deployer key", r.Name))
+			}
+		}
+
+		for _, field := range []struct {
+			name  string
+			value *string
+		}{
+			{"address", &r.Address},
+			{"deployer_address", &r.DeployerAddress},
+			{"implementation_address", &r.Implementation},
+		} {
+			if checksummed := config.ChecksumAddress(*field.value); checksummed != *field.value {
+				*field.value = checksummed
+				changes = append(changes, fmt.Sprintf("%s: checksummed %s", r.Name, field.name))
+			}
+		}
+	}
+
+	// Later records win, matching how the latest deployment of a name is resolved
+	latest := make(map[string]int)
+	for i, r := range records {
+		latest[strings.ToLower(r.Name)+"@"+strings.ToLower(r.Address)] = i
+	}
+	deduped := make([]config.DeploymentRecord, 0, len(latest))
+	for i, r := range records {
+		if latest[strings.ToLower(r.Name)+"@"+strings.ToLower(r.Address)] != i {
+			changes = append(changes, fmt.Sprintf("%s: removed duplicate record for %s", r.Name, r.Address))
+			continue
+		}
+		deduped = append(deduped, r)
+	}
+
+	out, err := json.MarshalIndent(deduped, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal deployments: %w", err)
+	}
+	return out, changes, nil
+}
+
+// migrateAccountsJSON fills in addresses derivable from each account's key, makes the delegated
+// address each account's identity, and checksums Ethereum addresses
+func migrateAccountsJSON(data []byte) ([]byte, []string, error) {
+	var accounts AccountsFile
+	if err := json.Unmarshal(data, &accounts); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse accounts file: %w", err)
+	}
+	if accounts.Accounts == nil {
+		accounts.Accounts = make(map[string]AccountInfo)
+	}
+
+	roles := make([]string, 0, len(accounts.Accounts))
+	for role := range accounts.Accounts {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+
+	before := make(map[string]AccountInfo, len(accounts.Accounts))
+	for _, role := range roles {
+		info := accounts.Accounts[role]
+		before[role] = info
+
+		if info.EthAddress == "" && info.PrivateKey != "" {
+			if key, err := parsePrivateKey(info.PrivateKey); err == nil {
+				info.EthAddress = crypto.PubkeyToAddress(key.PublicKey).Hex()
+			}
+		}
+		if info.Address == "" && info.EthAddress != "" {
+			if addr, err := delegatedAddress(info.EthAddress); err == nil {
+				info.Address = addr.String()
+			}
+		}
+		info.EthAddress = config.ChecksumAddress(info.EthAddress)
+		accounts.Accounts[role] = info
+	}
+	accounts.normalize()
+
+	var changes []string
+	for _, role := range roles {
+		if accounts.Accounts[role] != before[role] {
+			changes = append(changes, fmt.Sprintf("%s: updated addresses", role))
+		}
+	}
+
+	out, err := json.MarshalIndent(accounts, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal accounts: %w", err)
+	}
+	return out, changes, nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it into place, so
+// readers never see a partially written file
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
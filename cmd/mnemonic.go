@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/ethtypes"
+	"github.com/filecoin-project/lotus/chain/wallet/key"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// DefaultDerivationPath is the BIP-44 Ethereum account path; the account index is appended to it
+const DefaultDerivationPath = "m/44'/60'/0'/0"
+
+// GenerateMnemonic returns a new 12-word BIP-39 mnemonic
+func GenerateMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(128)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate entropy: %w", err)
+	}
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate mnemonic: %w", err)
+	}
+	return mnemonic, nil
+}
+
+// NewAccountFromMnemonic deterministically derives the account at basePath/index from a BIP-39 mnemonic
+func NewAccountFromMnemonic(mnemonic, basePath string, index uint32) (*key.Key, ethtypes.EthAddress, address.Address, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, ethtypes.EthAddress{}, address.Address{}, fmt.Errorf("invalid BIP-39 mnemonic")
+	}
+
+	k, err := NewKeyFromSeed(bip39.NewSeed(mnemonic, ""), basePath, index)
+	if err != nil {
+		return nil, ethtypes.EthAddress{}, address.Address{}, err
+	}
+
+	return accountFromKey(k)
+}
+
+// NewKeyFromSeed deterministically derives the secp256k1 key at basePath/index from a BIP-32 seed
+func NewKeyFromSeed(seed []byte, basePath string, index uint32) (*key.Key, error) {
+	path, err := accounts.ParseDerivationPath(basePath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid derivation path %q: %w", basePath, err)
+	}
+	path = append(path, index)
+
+	privateKey, err := deriveHDKey(seed, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive %s: %w", path, err)
+	}
+
+	k, err := key.NewKey(types.KeyInfo{
+		Type:       types.KTSecp256k1,
+		PrivateKey: privateKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load derived key: %w", err)
+	}
+	return k, nil
+}
+
+// parseSeed decodes a hex BIP-32 seed, which must be 16 to 64 bytes
+func parseSeed(seedHex string) ([]byte, error) {
+	seed, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(seedHex, "0x"), "0X"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid seed: %w", err)
+	}
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("invalid seed: must be 16 to 64 bytes, got %d", len(seed))
+	}
+	return seed, nil
+}
+
+// deriveHDKey walks a BIP-32 derivation path from the seed and returns the private key bytes
+func deriveHDKey(seed []byte, path accounts.DerivationPath) ([]byte, error) {
+	curveOrder := crypto.S256().Params().N
+
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	k, chainCode := new(big.Int).SetBytes(sum[:32]), sum[32:]
+	if k.Sign() == 0 || k.Cmp(curveOrder) >= 0 {
+		return nil, fmt.Errorf("invalid master key")
+	}
+
+	for _, index := range path {
+		var data []byte
+		if index >= 0x80000000 {
+			data = append([]byte{0}, math.PaddedBigBytes(k, 32)...)
+		} else {
+			priv, err := crypto.ToECDSA(math.PaddedBigBytes(k, 32))
+			if err != nil {
+				return nil, err
+			}
+			data = crypto.CompressPubkey(&priv.PublicKey)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+
+		tweak := new(big.Int).SetBytes(sum[:32])
+		if tweak.Cmp(curveOrder) >= 0 {
+			return nil, fmt.Errorf("invalid child key at index %d", index)
+		}
+		k = tweak.Add(tweak, k)
+		k.Mod(k, curveOrder)
+		if k.Sign() == 0 {
+			return nil, fmt.Errorf("invalid child key at index %d", index)
+		}
+		chainCode = sum[32:]
+	}
+
+	return math.PaddedBigBytes(k, 32), nil
+}
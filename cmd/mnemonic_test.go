@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// testMnemonic is the standard all-"abandon" BIP-39 test vector; its first account at
+// m/44'/60'/0'/0/0 is testAddress
+const (
+	testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	testAddress  = "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"
+)
+
+func TestDeriveHDKey(t *testing.T) {
+	path, err := accounts.ParseDerivationPath(DefaultDerivationPath + "/0")
+	if err != nil {
+		t.Fatalf("ParseDerivationPath: %v", err)
+	}
+
+	privateKey, err := deriveHDKey(bip39.NewSeed(testMnemonic, ""), path)
+	if err != nil {
+		t.Fatalf("deriveHDKey: %v", err)
+	}
+	key, err := crypto.ToECDSA(privateKey)
+	if err != nil {
+		t.Fatalf("derived key is not a valid secp256k1 key: %v", err)
+	}
+
+	if got := crypto.PubkeyToAddress(key.PublicKey).Hex(); got != testAddress {
+		t.Errorf("address = %s, want %s", got, testAddress)
+	}
+}
+
+func TestNewAccountFromMnemonic(t *testing.T) {
+	tests := []struct {
+		name     string
+		mnemonic string
+		index    uint32
+		want     string
+		wantErr  string
+	}{
+		{name: "first account", mnemonic: testMnemonic, index: 0, want: testAddress},
+		{name: "extra whitespace", mnemonic: "  " + strings.ReplaceAll(testMnemonic, " ", "\n "), index: 0, want: testAddress},
+		{name: "bad checksum word", mnemonic: strings.Replace(testMnemonic, "about", "abandon", 1), wantErr: "invalid BIP-39 mnemonic"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ethAddr, _, err := NewAccountFromMnemonic(tt.mnemonic, DefaultDerivationPath, tt.index)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewAccountFromMnemonic: %v", err)
+			}
+			if got := ethAddr.String(); !strings.EqualFold(got, tt.want) {
+				t.Errorf("address = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewKeyFromSeedIsDeterministic(t *testing.T) {
+	seed, err := parseSeed(hex.EncodeToString(bip39.NewSeed(testMnemonic, "")))
+	if err != nil {
+		t.Fatalf("parseSeed: %v", err)
+	}
+
+	derive := func(index uint32) string {
+		k, err := NewKeyFromSeed(seed, DefaultDerivationPath, index)
+		if err != nil {
+			t.Fatalf("NewKeyFromSeed(%d): %v", index, err)
+		}
+		_, ethAddr, _, err := accountFromKey(k)
+		if err != nil {
+			t.Fatalf("accountFromKey: %v", err)
+		}
+		return ethAddr.String()
+	}
+
+	first := derive(0)
+	if !strings.EqualFold(first, testAddress) {
+		t.Errorf("seed account 0 = %s, want the mnemonic's account %s", first, testAddress)
+	}
+	if again := derive(0); again != first {
+		t.Errorf("same seed derived %s, then %s", first, again)
+	}
+	if second := derive(1); second == first {
+		t.Errorf("accounts 0 and 1 share address %s", first)
+	}
+}
+
+func TestParseSeed(t *testing.T) {
+	tests := []struct {
+		name    string
+		seed    string
+		wantLen int
+		wantErr string
+	}{
+		{name: "16 bytes", seed: strings.Repeat("ab", 16), wantLen: 16},
+		{name: "0x prefix", seed: "0x" + strings.Repeat("ab", 32), wantLen: 32},
+		{name: "64 bytes", seed: strings.Repeat("00", 64), wantLen: 64},
+		{name: "too short", seed: strings.Repeat("ab", 15), wantErr: "must be 16 to 64 bytes"},
+		{name: "too long", seed: strings.Repeat("ab", 65), wantErr: "must be 16 to 64 bytes"},
+		{name: "not hex", seed: "xyz", wantErr: "invalid seed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seed, err := parseSeed(tt.seed)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSeed: %v", err)
+			}
+			if len(seed) != tt.wantLen {
+				t.Errorf("got %d bytes, want %d", len(seed), tt.wantLen)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/parthshah1/mpool-tx/config"
+	"github.com/urfave/cli/v2"
+)
+
+// jsonOutput reports whether a command should print JSON: either its own --json flag is set
+// or the global --output is json
+func jsonOutput(c *cli.Context) bool {
+	if c.Bool("json") {
+		return true
+	}
+	return cfg != nil && cfg.Output == config.OutputJSON
+}
+
+// printJSON prints v as indented JSON on stdout
+func printJSON(v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal output: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
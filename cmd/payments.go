@@ -7,17 +7,21 @@ import (
 	"math/big"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/filecoin-project/go-address"
 	filbig "github.com/filecoin-project/go-state-types/big"
 	lotustypes "github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/chain/types/ethtypes"
+	"github.com/parthshah1/mpool-tx/config"
 	"github.com/urfave/cli/v2"
 )
 
@@ -97,9 +101,18 @@ var PaymentsCmd = &cli.Command{
 				},
 				&cli.StringFlag{
 					Name:     "amount",
-					Usage:    "Token amount in wei",
+					Usage:    "Token amount (in wei, or whole tokens with --units tokens)",
 					Required: true,
 				},
+				&cli.StringFlag{
+					Name:  "units",
+					Usage: "Unit of --amount: wei, or tokens to scale by the token's decimals()",
+					Value: "wei",
+				},
+				&cli.StringFlag{
+					Name:  "abi",
+					Usage: "Token ABI file used to select the mint overload when --contract-address is used",
+				},
 				&cli.StringFlag{
 					Name:  "fil",
 					Usage: "Optional FIL amount to send to the derived Filecoin address",
@@ -109,6 +122,11 @@ var PaymentsCmd = &cli.Command{
 					Name:  "minter-private-key",
 					Usage: "Minter private key (required if --contract-address is used)",
 				},
+				&cli.BoolFlag{
+					Name:  "wait",
+					Usage: "Wait for the mint and FIL funding to confirm and verify the recipient's balances increased",
+					Value: true,
+				},
 			},
 			Action: mintAndFundPrivateKey,
 		},
@@ -213,6 +231,53 @@ var PaymentsCmd = &cli.Command{
 			},
 			Action: approveOperator,
 		},
+		{
+			Name:  "setup",
+			Usage: "Approve, deposit, and approve an operator in one step, waiting for each transaction. On failure the token approval is reset to 0; a completed deposit or operator approval is not rolled back",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "workspace",
+					Usage:    "Workspace directory",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "token",
+					Usage:    "Token contract name",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "amount",
+					Usage:    "Amount in wei to approve and deposit",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "operator",
+					Usage:    "Operator address (e.g., WarmStorage contract)",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "rate-allowance",
+					Usage:    "Rate allowance in wei per epoch",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "lockup-allowance",
+					Usage:    "Lockup allowance in wei",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "max-lockup-period",
+					Usage:    "Max lockup period in epochs",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "from",
+					Usage:    "From role name",
+					Required: true,
+				},
+			},
+			Action: setupPayments,
+		},
 		{
 			Name:  "balance",
 			Usage: "Check balance (token balance or Payments contract balance)",
@@ -227,11 +292,16 @@ var PaymentsCmd = &cli.Command{
 					Usage:    "Account role to check",
 					Required: true,
 				},
-				&cli.StringFlag{
+				&cli.StringSliceFlag{
 					Name:     "contract",
-					Usage:    "Contract name (e.g., USDFC for token balance, Payments for deposited balance)",
+					Usage:    "Contract name (e.g., USDFC for token balance, Payments for deposited balance); repeat to check several",
 					Required: true,
 				},
+				&cli.StringFlag{
+					Name:  "block",
+					Value: "latest",
+					Usage: "Block to read at: latest, pending, finalized, safe, earliest, or a block number",
+				},
 			},
 			Action: checkBalance,
 		},
@@ -283,12 +353,12 @@ func mintTokens(c *cli.Context) error {
 		return err
 	}
 
-	toAccount, ok := accounts.Accounts[toRole]
+	_, toAccount, ok := findAccount(c.Context, accounts, toRole)
 	if !ok {
 		return fmt.Errorf("account role '%s' not found", toRole)
 	}
 
-	minterAccount, ok := accounts.Accounts[minterRole]
+	_, minterAccount, ok := findAccount(c.Context, accounts, minterRole)
 	if !ok {
 		return fmt.Errorf("minter role '%s' not found", minterRole)
 	}
@@ -304,26 +374,27 @@ func mintTokens(c *cli.Context) error {
 		return fmt.Errorf("invalid private key for minter '%s': %w", minterRole, err)
 	}
 
-	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(31415926))
+	cfg, err := loadWorkspaceConfig(c.Context, workspace)
 	if err != nil {
-		return fmt.Errorf("failed to create transactor: %w", err)
+		return err
 	}
 
-	tokenABI, err := os.ReadFile(tokenRecord.ABIPath)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(cfg.ChainID))
 	if err != nil {
-		return fmt.Errorf("failed to read ABI: %w", err)
+		return fmt.Errorf("failed to create transactor: %w", err)
 	}
+	auth.Context = c.Context
 
-	client, err := ethclient.Dial(cfg.RPC)
+	parsedABI, err := loadABI(tokenRecord.ABIPath)
 	if err != nil {
-		return fmt.Errorf("failed to connect: %w", err)
+		return err
 	}
-	defer client.Close()
 
-	parsedABI, err := parseABI(tokenABI)
+	client, err := config.DialEthClient(cfg.RPC, cfg.Token)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to connect: %w", err)
 	}
+	defer client.Close()
 	contract := bind.NewBoundContract(common.HexToAddress(tokenRecord.Address), parsedABI, client, client, client)
 
 	tx, err := contract.Transact(auth, "mint", common.HexToAddress(toAccount.EthAddress), amount)
@@ -344,6 +415,7 @@ func mintAndFundPrivateKey(c *cli.Context) error {
 	amountStr := c.String("amount")
 	filAmountStr := strings.TrimSpace(c.String("fil"))
 	minterKey := c.String("minter-private-key")
+	units := c.String("units")
 
 	if tokenName == "" {
 		tokenName = "USDFC"
@@ -354,18 +426,28 @@ func mintAndFundPrivateKey(c *cli.Context) error {
 	if amountStr == "" {
 		return fmt.Errorf("amount is required")
 	}
+	if units != "wei" && units != "tokens" {
+		return fmt.Errorf("invalid --units %q: expected wei or tokens", units)
+	}
 
 	var tokenAddr string
 	var tokenABI []byte
 
 	if contractAddress != "" {
-		// Direct contract address provided - use standard ERC20 ABI for mint
+		// Direct contract address provided - use --abi if given, else the standard ERC20 mint ABI
+		if _, err := parseHexAddress("contract", contractAddress); err != nil {
+			return err
+		}
 		tokenAddr = contractAddress
-		if minterKey == "" {
-			return fmt.Errorf("--minter-private-key is required when using --contract-address")
+		if abiPath := c.String("abi"); abiPath != "" {
+			data, err := os.ReadFile(abiPath)
+			if err != nil {
+				return fmt.Errorf("failed to read ABI: %w", err)
+			}
+			tokenABI = data
+		} else {
+			tokenABI = []byte(standardMintABI)
 		}
-		// Standard ERC20 mint ABI
-		tokenABI = []byte(`[{"inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"name":"mint","outputs":[],"stateMutability":"nonpayable","type":"function"}]`)
 	} else {
 		// Use workspace deployments
 		if workspace == "" {
@@ -390,21 +472,22 @@ func mintAndFundPrivateKey(c *cli.Context) error {
 
 		if minterKey == "" {
 			minterKey = tokenRecord.PrivateKey
-			if minterKey == "" {
-				return fmt.Errorf("deployment record for %s is missing deployer private key; supply --minter-private-key", tokenName)
-			}
 		}
 
 		tokenABI, err = os.ReadFile(tokenRecord.ABIPath)
 		if err != nil {
 			// Fall back to standard ERC20 mint ABI
-			tokenABI = []byte(`[{"inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"name":"mint","outputs":[],"stateMutability":"nonpayable","type":"function"}]`)
+			tokenABI = []byte(standardMintABI)
 		}
 	}
 
-	minterECDSA, err := parsePrivateKey(minterKey)
+	parsedABI, err := parseABI(tokenABI)
 	if err != nil {
-		return fmt.Errorf("invalid minter private key: %w", err)
+		return err
+	}
+	mint, err := selectMintMethod(parsedABI)
+	if err != nil {
+		return fmt.Errorf("cannot mint %s: %w", tokenAddr, err)
 	}
 
 	recipientECDSA, err := parsePrivateKey(recipientKey)
@@ -412,39 +495,51 @@ func mintAndFundPrivateKey(c *cli.Context) error {
 		return fmt.Errorf("invalid recipient private key: %w", err)
 	}
 
-	tokenAmount := new(big.Int)
-	if _, ok := tokenAmount.SetString(amountStr, 10); !ok {
-		return fmt.Errorf("invalid amount: %s", amountStr)
+	// A self-minting token credits the sender, so the recipient signs its own mint
+	signerECDSA := recipientECDSA
+	if !mint.SelfMint {
+		if minterKey == "" {
+			if contractAddress != "" {
+				return fmt.Errorf("--minter-private-key is required when using --contract-address")
+			}
+			return fmt.Errorf("deployment record for %s is missing deployer private key; supply --minter-private-key", tokenName)
+		}
+		signerECDSA, err = parsePrivateKey(minterKey)
+		if err != nil {
+			return fmt.Errorf("invalid minter private key: %w", err)
+		}
 	}
 
-	client, err := ethclient.Dial(cfg.RPC)
+	cfg, err := loadWorkspaceConfig(c.Context, workspace)
 	if err != nil {
-		return fmt.Errorf("failed to connect: %w", err)
+		return err
 	}
-	defer client.Close()
 
-	auth, err := bind.NewKeyedTransactorWithChainID(minterECDSA, big.NewInt(31415926))
+	client, err := config.DialEthClient(cfg.RPC, cfg.Token)
 	if err != nil {
-		return fmt.Errorf("failed to create transactor: %w", err)
+		return fmt.Errorf("failed to connect: %w", err)
 	}
+	defer client.Close()
 
-	parsedABI, err := parseABI(tokenABI)
-	if err != nil {
-		return err
-	}
 	contract := bind.NewBoundContract(common.HexToAddress(tokenAddr), parsedABI, client, client, client)
 
-	recipientEthAddr := crypto.PubkeyToAddress(recipientECDSA.PublicKey)
-
-	tx, err := contract.Transact(auth, "mint", recipientEthAddr, tokenAmount)
-	if err != nil {
-		return fmt.Errorf("mint failed: %w", err)
+	tokenAmount := new(big.Int)
+	if units == "tokens" {
+		decimals, err := tokenDecimals(c.Context, contract, parsedABI)
+		if err != nil {
+			return err
+		}
+		tokenAmount, err = parseTokenUnits(amountStr, decimals)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Amount: %s tokens = %s wei (%d decimals)\n", amountStr, tokenAmount, decimals)
+	} else if _, ok := tokenAmount.SetString(amountStr, 10); !ok {
+		return fmt.Errorf("invalid amount: %s", amountStr)
 	}
 
-	fmt.Printf("Minted %s wei to %s\n", amountStr, recipientEthAddr.Hex())
-	fmt.Printf("Mint transaction: %s\n", tx.Hash().Hex())
-
-	filAmountStr = strings.TrimSpace(filAmountStr)
+	recipientEthAddr := crypto.PubkeyToAddress(recipientECDSA.PublicKey)
+	wait := c.Bool("wait")
 
 	castAddr, err := ethtypes.CastEthAddress(recipientEthAddr.Bytes())
 	if err != nil {
@@ -459,10 +554,62 @@ func mintAndFundPrivateKey(c *cli.Context) error {
 	fmt.Printf("Derived Ethereum address: %s\n", recipientEthAddr.Hex())
 	fmt.Printf("Derived Filecoin address: %s\n", filAddr)
 
-	if filAmountStr == "" || filAmountStr == "0" {
+	// Fund first so a self-minting recipient can pay for its own mint
+	if filAmountStr != "" && filAmountStr != "0" {
+		if err := fundRecipientFIL(c.Context, client, recipientEthAddr, filAddr, filAmountStr, wait); err != nil {
+			return err
+		}
+	}
+
+	auth, err := bind.NewKeyedTransactorWithChainID(signerECDSA, big.NewInt(cfg.ChainID))
+	if err != nil {
+		return fmt.Errorf("failed to create transactor: %w", err)
+	}
+	auth.Context = c.Context
+
+	var tokenBefore *big.Int
+	if wait {
+		tokenBefore, err = tokenBalanceOf(c.Context, client, common.HexToAddress(tokenAddr), recipientEthAddr)
+		if err != nil {
+			return err
+		}
+	}
+
+	var tx *types.Transaction
+	if mint.SelfMint {
+		fmt.Printf("Self-minting via %s\n", parsedABI.Methods[mint.Name].Sig)
+		tx, err = contract.Transact(auth, mint.Name, tokenAmount)
+	} else {
+		tx, err = contract.Transact(auth, mint.Name, recipientEthAddr, tokenAmount)
+	}
+	if err != nil {
+		return fmt.Errorf("mint failed: %w", err)
+	}
+	fmt.Printf("Mint transaction: %s\n", tx.Hash().Hex())
+
+	if !wait {
+		fmt.Printf("Submitted mint of %s wei to %s\n", tokenAmount, recipientEthAddr.Hex())
 		return nil
 	}
 
+	if err := waitForSuccess(c.Context, client, tx); err != nil {
+		return fmt.Errorf("mint failed: %w", err)
+	}
+	tokenAfter, err := tokenBalanceOf(c.Context, client, common.HexToAddress(tokenAddr), recipientEthAddr)
+	if err != nil {
+		return err
+	}
+	if tokenAfter.Cmp(tokenBefore) <= 0 {
+		return fmt.Errorf("mint transaction %s succeeded but the token balance of %s did not increase (still %s)", tx.Hash().Hex(), recipientEthAddr.Hex(), tokenAfter)
+	}
+	fmt.Printf("Minted %s wei to %s (balance: %s)\n", tokenAmount, recipientEthAddr.Hex(), tokenAfter)
+
+	return nil
+}
+
+// fundRecipientFIL sends FIL from the node's default wallet and, when wait is set,
+// verifies the recipient's balance increased
+func fundRecipientFIL(ctx context.Context, client *ethclient.Client, recipient common.Address, filAddr address.Address, filAmountStr string, wait bool) error {
 	filAmount, err := filbig.FromString(filAmountStr)
 	if err != nil {
 		return fmt.Errorf("invalid FIL amount: %w", err)
@@ -470,17 +617,123 @@ func mintAndFundPrivateKey(c *cli.Context) error {
 
 	fundAmount := lotustypes.BigMul(filAmount, lotustypes.NewInt(1e18))
 
-	smsg, err := FundWallet(context.Background(), filAddr, fundAmount, true)
+	var filBefore *big.Int
+	if wait {
+		filBefore, err = client.BalanceAt(ctx, recipient, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get FIL balance of %s: %w", recipient.Hex(), err)
+		}
+	}
+
+	smsg, err := FundWallet(ctx, filAddr, fundAmount, wait)
 	if err != nil {
 		return fmt.Errorf("failed to fund wallet: %w", err)
 	}
-
-	fmt.Printf("Funded %s FIL to %s\n", filAmountStr, filAddr)
 	fmt.Printf("Funding message CID: %s\n", smsg.Cid())
 
+	if !wait {
+		fmt.Printf("Submitted funding of %s FIL to %s\n", filAmountStr, filAddr)
+		return nil
+	}
+
+	filAfter, err := client.BalanceAt(ctx, recipient, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get FIL balance of %s: %w", recipient.Hex(), err)
+	}
+	if filAfter.Cmp(filBefore) <= 0 {
+		return fmt.Errorf("funding message %s confirmed but the FIL balance of %s did not increase", smsg.Cid(), filAddr)
+	}
+	fmt.Printf("Funded %s FIL to %s (balance: %s)\n", filAmountStr, filAddr, lotustypes.FIL(lotustypes.BigInt{Int: filAfter}))
 	return nil
 }
 
+// standardMintABI is the mint(address,uint256) ABI assumed when no token ABI is available
+const standardMintABI = `[{"inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"name":"mint","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+
+// mintMethod is the mint overload selected from a token ABI
+type mintMethod struct {
+	// Name is the method name as bound by go-ethereum; overloads are suffixed (mint0, mint1, ...)
+	Name string
+	// SelfMint is set for mint(uint256), which credits the sender
+	SelfMint bool
+}
+
+// selectMintMethod picks mint(address,uint256) from a token ABI, falling back to
+// mint(uint256) for self-minting tokens
+func selectMintMethod(parsed abi.ABI) (*mintMethod, error) {
+	var selfMint *mintMethod
+	var found []string
+	for name, method := range parsed.Methods {
+		if method.RawName != "mint" {
+			continue
+		}
+		found = append(found, method.Sig)
+
+		inputs := method.Inputs
+		switch {
+		case len(inputs) == 2 && inputs[0].Type.T == abi.AddressTy && inputs[1].Type.T == abi.UintTy:
+			return &mintMethod{Name: name}, nil
+		case len(inputs) == 1 && inputs[0].Type.T == abi.UintTy:
+			selfMint = &mintMethod{Name: name, SelfMint: true}
+		}
+	}
+
+	if selfMint != nil {
+		return selfMint, nil
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("token ABI has no mint method")
+	}
+	sort.Strings(found)
+	return nil, fmt.Errorf("no supported mint overload (found %s; supported: mint(address,uint256), mint(uint256))", strings.Join(found, ", "))
+}
+
+// tokenDecimals reads decimals() from a token, assuming 18 when the ABI does not declare it
+func tokenDecimals(ctx context.Context, contract *bind.BoundContract, parsed abi.ABI) (uint8, error) {
+	if _, ok := parsed.Methods["decimals"]; !ok {
+		fmt.Println("Token ABI has no decimals(); assuming 18")
+		return 18, nil
+	}
+
+	var out []interface{}
+	if err := contract.Call(&bind.CallOpts{Context: ctx}, &out, "decimals"); err != nil {
+		return 0, fmt.Errorf("failed to read token decimals: %w", err)
+	}
+	return *abi.ConvertType(out[0], new(uint8)).(*uint8), nil
+}
+
+// parseTokenUnits converts a decimal token amount such as "1.5" to base units
+func parseTokenUnits(amount string, decimals uint8) (*big.Int, error) {
+	whole, frac, _ := strings.Cut(amount, ".")
+	if len(frac) > int(decimals) {
+		return nil, fmt.Errorf("invalid amount %s: more than %d decimal places", amount, decimals)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	value, ok := new(big.Int).SetString(whole+frac+strings.Repeat("0", int(decimals)-len(frac)), 10)
+	if !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("invalid amount: %s", amount)
+	}
+	return value, nil
+}
+
+// tokenBalanceOf reads an ERC20 balance
+func tokenBalanceOf(ctx context.Context, client *ethclient.Client, token, owner common.Address) (*big.Int, error) {
+	tokenABI, err := parseABI([]byte(erc20ReadABI))
+	if err != nil {
+		return nil, err
+	}
+	contract := bind.NewBoundContract(token, tokenABI, client, client, client)
+
+	var out []interface{}
+	if err := contract.Call(&bind.CallOpts{Context: ctx}, &out, "balanceOf", owner); err != nil {
+		return nil, fmt.Errorf("failed to read token balance of %s: %w", owner.Hex(), err)
+	}
+	return *abi.ConvertType(out[0], new(*big.Int)).(**big.Int), nil
+}
+
 func approveTokens(c *cli.Context) error {
 	workspace := c.String("workspace")
 	tokenName := c.String("token")
@@ -508,7 +761,7 @@ func approveTokens(c *cli.Context) error {
 		return err
 	}
 
-	fromAccount, ok := accounts.Accounts[fromRole]
+	_, fromAccount, ok := findAccount(c.Context, accounts, fromRole)
 	if !ok {
 		return fmt.Errorf("account role '%s' not found", fromRole)
 	}
@@ -524,26 +777,27 @@ func approveTokens(c *cli.Context) error {
 		return fmt.Errorf("invalid private key for '%s': %w", fromRole, err)
 	}
 
-	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(31415926))
+	cfg, err := loadWorkspaceConfig(c.Context, workspace)
 	if err != nil {
-		return fmt.Errorf("failed to create transactor: %w", err)
+		return err
 	}
 
-	tokenABI, err := os.ReadFile(tokenRecord.ABIPath)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(cfg.ChainID))
 	if err != nil {
-		return fmt.Errorf("failed to read ABI: %w", err)
+		return fmt.Errorf("failed to create transactor: %w", err)
 	}
+	auth.Context = c.Context
 
-	client, err := ethclient.Dial(cfg.RPC)
+	parsedABI, err := loadABI(tokenRecord.ABIPath)
 	if err != nil {
-		return fmt.Errorf("failed to connect: %w", err)
+		return err
 	}
-	defer client.Close()
 
-	parsedABI, err := parseABI(tokenABI)
+	client, err := config.DialEthClient(cfg.RPC, cfg.Token)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to connect: %w", err)
 	}
+	defer client.Close()
 	contract := bind.NewBoundContract(common.HexToAddress(tokenRecord.Address), parsedABI, client, client, client)
 
 	tx, err := contract.Transact(auth, "approve", common.HexToAddress(spenderRecord.Address), amount)
@@ -582,7 +836,7 @@ func depositTokens(c *cli.Context) error {
 		return err
 	}
 
-	fromAccount, ok := accounts.Accounts[fromRole]
+	_, fromAccount, ok := findAccount(c.Context, accounts, fromRole)
 	if !ok {
 		return fmt.Errorf("account role '%s' not found", fromRole)
 	}
@@ -598,26 +852,32 @@ func depositTokens(c *cli.Context) error {
 		return fmt.Errorf("invalid private key for '%s': %w", fromRole, err)
 	}
 
-	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(31415926))
+	cfg, err := loadWorkspaceConfig(c.Context, workspace)
+	if err != nil {
+		return err
+	}
+
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(cfg.ChainID))
 	if err != nil {
 		return fmt.Errorf("failed to create transactor: %w", err)
 	}
+	auth.Context = c.Context
 
-	paymentsABI, err := os.ReadFile(paymentsRecord.ABIPath)
+	parsedABI, err := loadABI(paymentsRecord.ABIPath)
 	if err != nil {
-		return fmt.Errorf("failed to read ABI: %w", err)
+		return err
 	}
 
-	client, err := ethclient.Dial(cfg.RPC)
+	client, err := config.DialEthClient(cfg.RPC, cfg.Token)
 	if err != nil {
 		return fmt.Errorf("failed to connect: %w", err)
 	}
 	defer client.Close()
 
-	parsedABI, err := parseABI(paymentsABI)
-	if err != nil {
+	if err := checkDepositAllowance(c.Context, client, tokenRecord, common.HexToAddress(fromAccount.EthAddress), common.HexToAddress(paymentsRecord.Address), amount); err != nil {
 		return err
 	}
+
 	contract := bind.NewBoundContract(common.HexToAddress(paymentsRecord.Address), parsedABI, client, client, client)
 
 	tx, err := contract.Transact(auth, "deposit", common.HexToAddress(tokenRecord.Address), common.HexToAddress(fromAccount.EthAddress), amount)
@@ -639,6 +899,10 @@ func approveOperator(c *cli.Context) error {
 	maxLockupPeriodStr := c.String("max-lockup-period")
 	fromRole := c.String("from")
 
+	if _, err := parseHexAddress("operator", operatorAddr); err != nil {
+		return err
+	}
+
 	deployments, err := loadDeployments(workspace)
 	if err != nil {
 		return err
@@ -659,7 +923,7 @@ func approveOperator(c *cli.Context) error {
 		return err
 	}
 
-	fromAccount, ok := accounts.Accounts[fromRole]
+	_, fromAccount, ok := findAccount(c.Context, accounts, fromRole)
 	if !ok {
 		return fmt.Errorf("account role '%s' not found", fromRole)
 	}
@@ -687,26 +951,27 @@ func approveOperator(c *cli.Context) error {
 		return fmt.Errorf("invalid private key for '%s': %w", fromRole, err)
 	}
 
-	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(31415926))
+	cfg, err := loadWorkspaceConfig(c.Context, workspace)
 	if err != nil {
-		return fmt.Errorf("failed to create transactor: %w", err)
+		return err
 	}
 
-	paymentsABI, err := os.ReadFile(paymentsRecord.ABIPath)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(cfg.ChainID))
 	if err != nil {
-		return fmt.Errorf("failed to read ABI: %w", err)
+		return fmt.Errorf("failed to create transactor: %w", err)
 	}
+	auth.Context = c.Context
 
-	client, err := ethclient.Dial(cfg.RPC)
+	parsedABI, err := loadABI(paymentsRecord.ABIPath)
 	if err != nil {
-		return fmt.Errorf("failed to connect: %w", err)
+		return err
 	}
-	defer client.Close()
 
-	parsedABI, err := parseABI(paymentsABI)
+	client, err := config.DialEthClient(cfg.RPC, cfg.Token)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to connect: %w", err)
 	}
+	defer client.Close()
 	contract := bind.NewBoundContract(common.HexToAddress(paymentsRecord.Address), parsedABI, client, client, client)
 
 	tx, err := contract.Transact(auth, "setOperatorApproval",
@@ -725,12 +990,44 @@ func approveOperator(c *cli.Context) error {
 	return nil
 }
 
-func checkBalance(c *cli.Context) error {
+// setupStep is one transaction in `payments setup`, with undo, if set, reverting it when a
+// later step fails (undo returns a nil transaction when there is nothing left to revert)
+type setupStep struct {
+	name string
+	send func() (*types.Transaction, error)
+	undo func() (*types.Transaction, error)
+}
+
+func setupPayments(c *cli.Context) error {
 	workspace := c.String("workspace")
-	accountRole := c.String("account")
-	contractName := c.String("contract")
+	tokenName := c.String("token")
+	amountStr := c.String("amount")
+	operatorAddr := c.String("operator")
+	fromRole := c.String("from")
+
+	operator, err := parseHexAddress("operator", operatorAddr)
+	if err != nil {
+		return err
+	}
+
+	amount, ok := new(big.Int).SetString(amountStr, 10)
+	if !ok {
+		return fmt.Errorf("invalid amount: %s", amountStr)
+	}
+	rateAllowance, ok := new(big.Int).SetString(c.String("rate-allowance"), 10)
+	if !ok {
+		return fmt.Errorf("invalid rate allowance: %s", c.String("rate-allowance"))
+	}
+	lockupAllowance, ok := new(big.Int).SetString(c.String("lockup-allowance"), 10)
+	if !ok {
+		return fmt.Errorf("invalid lockup allowance: %s", c.String("lockup-allowance"))
+	}
+	maxLockupPeriod, ok := new(big.Int).SetString(c.String("max-lockup-period"), 10)
+	if !ok {
+		return fmt.Errorf("invalid max lockup period: %s", c.String("max-lockup-period"))
+	}
 
-	cfg, err := loadWorkspaceConfig()
+	deployments, err := loadDeployments(workspace)
 	if err != nil {
 		return err
 	}
@@ -740,128 +1037,461 @@ func checkBalance(c *cli.Context) error {
 		return err
 	}
 
-	account, exists := accounts.Accounts[accountRole]
-	if !exists {
-		return fmt.Errorf("account role '%s' not found", accountRole)
+	tokenRecord, err := findContract(deployments, tokenName)
+	if err != nil {
+		return err
 	}
 
-	deployments, err := loadDeployments(workspace)
+	paymentsRecord, err := findContract(deployments, "Payments")
 	if err != nil {
 		return err
 	}
 
-	client, err := ethclient.Dial(cfg.RPC)
+	_, fromAccount, ok := findAccount(c.Context, accounts, fromRole)
+	if !ok {
+		return fmt.Errorf("account role '%s' not found", fromRole)
+	}
+
+	privateKey, err := parsePrivateKey(fromAccount.PrivateKey)
 	if err != nil {
-		return fmt.Errorf("failed to connect to RPC: %w", err)
+		return fmt.Errorf("invalid private key for '%s': %w", fromRole, err)
+	}
+
+	cfg, err := loadWorkspaceConfig(c.Context, workspace)
+	if err != nil {
+		return err
+	}
+
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(cfg.ChainID))
+	if err != nil {
+		return fmt.Errorf("failed to create transactor: %w", err)
+	}
+	auth.Context = c.Context
+
+	tokenABI, err := loadABI(tokenRecord.ABIPath)
+	if err != nil {
+		return err
+	}
+
+	paymentsABI, err := loadABI(paymentsRecord.ABIPath)
+	if err != nil {
+		return err
+	}
+
+	client, err := config.DialEthClient(cfg.RPC, cfg.Token)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
 	}
 	defer client.Close()
 
-	isPaymentsContract := strings.EqualFold(contractName, "Payments")
+	tokenAddr := common.HexToAddress(tokenRecord.Address)
+	paymentsAddr := common.HexToAddress(paymentsRecord.Address)
+	owner := common.HexToAddress(fromAccount.EthAddress)
 
-	if isPaymentsContract {
-		paymentsRecord, err := findContract(deployments, "Payments")
-		if err != nil {
-			return err
-		}
+	token := bind.NewBoundContract(tokenAddr, tokenABI, client, client, client)
+	payments := bind.NewBoundContract(paymentsAddr, paymentsABI, client, client, client)
 
-		abiData, err := os.ReadFile(paymentsRecord.ABIPath)
-		if err != nil {
-			return fmt.Errorf("failed to read ABI: %w", err)
-		}
+	steps := []setupStep{
+		{
+			name: fmt.Sprintf("approve Payments to spend %s %s", amount, tokenName),
+			send: func() (*types.Transaction, error) {
+				return token.Transact(auth, "approve", paymentsAddr, amount)
+			},
+			undo: func() (*types.Transaction, error) {
+				// A completed deposit has already used up the allowance
+				var out []interface{}
+				if err := token.Call(&bind.CallOpts{Context: c.Context}, &out, "allowance", owner, paymentsAddr); err != nil {
+					return nil, fmt.Errorf("failed to read %s allowance: %w", tokenName, err)
+				}
+				if allowance := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int); allowance.Sign() == 0 {
+					return nil, nil
+				}
+				return token.Transact(auth, "approve", paymentsAddr, big.NewInt(0))
+			},
+		},
+		{
+			name: fmt.Sprintf("deposit %s %s into Payments", amount, tokenName),
+			send: func() (*types.Transaction, error) {
+				if err := checkDepositAllowance(c.Context, client, tokenRecord, owner, paymentsAddr, amount); err != nil {
+					return nil, err
+				}
+				return payments.Transact(auth, "deposit", tokenAddr, owner, amount)
+			},
+		},
+		{
+			name: fmt.Sprintf("approve operator %s", operator.Hex()),
+			send: func() (*types.Transaction, error) {
+				return payments.Transact(auth, "setOperatorApproval", tokenAddr, operator, true, rateAllowance, lockupAllowance, maxLockupPeriod)
+			},
+		},
+	}
 
-		parsedABI, err := parseABI(abiData)
-		if err != nil {
-			return err
+	for i, step := range steps {
+		fmt.Printf("[%d/%d] %s\n", i+1, len(steps), step.name)
+
+		tx, err := step.send()
+		if err == nil {
+			fmt.Printf("  Tx: %s\n", tx.Hash().Hex())
+			err = waitForSuccess(c.Context, client, tx)
 		}
-		accountAddr := common.HexToAddress(account.EthAddress)
-		data, err := parsedABI.Pack("accountBalances", accountAddr)
 		if err != nil {
-			return fmt.Errorf("failed to pack accountBalances call: %w", err)
+			undoSetupSteps(c.Context, client, steps[:i])
+			return fmt.Errorf("payments setup failed at step %d (%s): %w (a deposit or operator approval already made is not rolled back)", i+1, step.name, err)
 		}
+	}
 
-		paymentsAddress := common.HexToAddress(paymentsRecord.Address)
-		result, err := client.CallContract(context.Background(), ethereum.CallMsg{
-			To:   &paymentsAddress,
-			Data: data,
-		}, nil)
-		if err != nil {
-			return fmt.Errorf("failed to call accountBalances: %w", err)
+	fmt.Printf("Payments setup complete for %s\n", fromRole)
+	return nil
+}
+
+// undoSetupSteps reverts the completed steps that can be undone, newest first, and reports
+// the ones left in place
+func undoSetupSteps(ctx context.Context, client *ethclient.Client, done []setupStep) {
+	for i := len(done) - 1; i >= 0; i-- {
+		step := done[i]
+		if step.undo == nil {
+			fmt.Printf("  Completed before failure, not rolled back: %s\n", step.name)
+			continue
 		}
 
-		var balance *big.Int
-		err = parsedABI.UnpackIntoInterface(&balance, "accountBalances", result)
+		tx, err := step.undo()
+		if err == nil && tx == nil {
+			continue
+		}
+		if err == nil {
+			err = waitForSuccess(ctx, client, tx)
+		}
 		if err != nil {
-			return fmt.Errorf("failed to unpack balance: %w", err)
+			fmt.Printf("  Warning: failed to undo %s: %v\n", step.name, err)
+			continue
 		}
+		fmt.Printf("  Undone: %s (%s)\n", step.name, tx.Hash().Hex())
+	}
+}
 
-		fmt.Printf("Account: %s (%s)\n", accountRole, account.EthAddress)
-		fmt.Printf("Payments Contract: %s\n", paymentsRecord.Address)
-		fmt.Printf("Balance in Payments: %s wei\n", balance.String())
+// waitForSuccess blocks until tx is mined and returns an error if it reverted
+func waitForSuccess(ctx context.Context, client *ethclient.Client, tx *types.Transaction) error {
+	ctx, cancel := context.WithTimeout(ctx, cfg.ContractTimeout)
+	defer cancel()
 
-		balanceFloat := new(big.Float).SetInt(balance)
-		divisor := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
-		tokenBalance := new(big.Float).Quo(balanceFloat, divisor)
-		fmt.Printf("Balance in Payments: %s tokens\n", tokenBalance.Text('f', 6))
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	if err != nil {
+		return fmt.Errorf("failed waiting for %s: %w", tx.Hash().Hex(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		if reason := replayRevertReason(ctx, client, tx, receipt.BlockNumber); reason != "" {
+			return fmt.Errorf("transaction %s reverted: %s", tx.Hash().Hex(), reason)
+		}
+		return fmt.Errorf("transaction %s reverted", tx.Hash().Hex())
+	}
+	return waitForConfirmations(ctx, tx)
+}
 
+// waitForConfirmations waits until the Filecoin message carrying tx has cfg.Confirmations
+// epochs on top of it, so a later step does not build on a transaction that may be reorged out.
+// With no confirmations configured the mined receipt is enough.
+func waitForConfirmations(ctx context.Context, tx *types.Transaction) error {
+	if cfg.Confirmations == 0 {
 		return nil
 	}
+	node := clientt.GetAPI()
+
+	hash := ethtypes.EthHash(tx.Hash())
+	msg, err := node.EthGetMessageCidByTransactionHash(ctx, &hash)
+	if err != nil {
+		return fmt.Errorf("failed to find the message for %s: %w", tx.Hash().Hex(), err)
+	}
+	if msg == nil {
+		return fmt.Errorf("no message found for %s", tx.Hash().Hex())
+	}
+
+	_, err = waitForMessage(ctx, node, *msg)
+	return err
+}
+
+// replayRevertReason re-executes a reverted transaction against the state before its block
+// to recover the revert reason, returning "" if none can be determined
+func replayRevertReason(ctx context.Context, client *ethclient.Client, tx *types.Transaction, block *big.Int) string {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return ""
+	}
+
+	var parent *big.Int
+	if block != nil && block.Sign() > 0 {
+		parent = new(big.Int).Sub(block, big.NewInt(1))
+	}
+
+	_, err = client.CallContract(ctx, ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}, parent)
+	if err == nil {
+		return ""
+	}
+	return config.RevertReason(err)
+}
+
+// erc20ReadABI covers the standard ERC20 views used for deposit prechecks
+const erc20ReadABI = `[{"inputs":[{"name":"account","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],"name":"allowance","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`
+
+// checkDepositAllowance verifies the owner holds enough tokens and has approved Payments
+// for amount, so a deposit that would revert fails early with an actionable error
+func checkDepositAllowance(ctx context.Context, client *ethclient.Client, tokenRecord *DeploymentRecord, owner, payments common.Address, amount *big.Int) error {
+	tokenABI, err := parseABI([]byte(erc20ReadABI))
+	if err != nil {
+		return err
+	}
+	token := bind.NewBoundContract(common.HexToAddress(tokenRecord.Address), tokenABI, client, client, client)
+
+	var out []interface{}
+	if err := token.Call(&bind.CallOpts{Context: ctx}, &out, "balanceOf", owner); err != nil {
+		return fmt.Errorf("failed to read %s balance: %w", tokenRecord.Name, err)
+	}
+	balance := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	out = nil
+	if err := token.Call(&bind.CallOpts{Context: ctx}, &out, "allowance", owner, payments); err != nil {
+		return fmt.Errorf("failed to read %s allowance: %w", tokenRecord.Name, err)
+	}
+	allowance := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	if balance.Cmp(amount) < 0 {
+		return fmt.Errorf("insufficient %s balance: have %s, need %s (mint or transfer tokens to %s first)", tokenRecord.Name, balance, amount, owner.Hex())
+	}
+	if allowance.Cmp(amount) < 0 {
+		return fmt.Errorf("insufficient %s allowance for Payments: approved %s, need %s (run `payments approve --token %s --spender Payments --amount %s` first)", tokenRecord.Name, allowance, amount, tokenRecord.Name, amount)
+	}
+
+	return nil
+}
+
+// balanceQuery is a single balance lookup against a token or the Payments contract
+type balanceQuery struct {
+	contractName string
+	record       *DeploymentRecord
+	parsedABI    abi.ABI
+	method       string
+	data         []byte
+}
+
+func checkBalance(c *cli.Context) error {
+	workspace := c.String("workspace")
+	accountRole := c.String("account")
+	contractNames := c.StringSlice("contract")
 
-	// Check token balance
-	tokenRecord, err := findContract(deployments, contractName)
+	block, err := config.ParseBlockTag(c.String("block"))
 	if err != nil {
 		return err
 	}
 
-	abiData, err := os.ReadFile(tokenRecord.ABIPath)
+	cfg, err := loadWorkspaceConfig(c.Context, workspace)
 	if err != nil {
-		return fmt.Errorf("failed to read ABI: %w", err)
+		return err
 	}
 
-	parsedABI, err := parseABI(abiData)
+	accounts, err := loadAccounts(workspace)
 	if err != nil {
 		return err
 	}
-	accountAddr := common.HexToAddress(account.EthAddress)
-	data, err := parsedABI.Pack("balanceOf", accountAddr)
+
+	_, account, exists := findAccount(c.Context, accounts, accountRole)
+	if !exists {
+		return fmt.Errorf("account role '%s' not found", accountRole)
+	}
+
+	deployments, err := loadDeployments(workspace)
 	if err != nil {
-		return fmt.Errorf("failed to pack balanceOf call: %w", err)
+		return err
 	}
 
-	tokenAddress := common.HexToAddress(tokenRecord.Address)
-	result, err := client.CallContract(context.Background(), ethereum.CallMsg{
-		To:   &tokenAddress,
-		Data: data,
-	}, nil)
+	client, err := config.DialEthClient(cfg.RPC, cfg.Token)
 	if err != nil {
-		return fmt.Errorf("failed to call balanceOf: %w", err)
+		return fmt.Errorf("failed to connect to RPC: %w", err)
 	}
+	defer client.Close()
 
-	var balance *big.Int
-	err = parsedABI.UnpackIntoInterface(&balance, "balanceOf", result)
+	accountAddr := common.HexToAddress(account.EthAddress)
+
+	queries := make([]balanceQuery, 0, len(contractNames))
+	for _, contractName := range contractNames {
+		q, err := newBalanceQuery(deployments, contractName, accountAddr)
+		if err != nil {
+			return err
+		}
+		queries = append(queries, q)
+	}
+
+	results, err := callBalanceQueries(c.Context, client, cfg.Multicall3, queries, block)
 	if err != nil {
-		return fmt.Errorf("failed to unpack balance: %w", err)
+		return err
 	}
 
 	fmt.Printf("Account: %s (%s)\n", accountRole, account.EthAddress)
-	fmt.Printf("Token: %s (%s)\n", contractName, tokenRecord.Address)
-	fmt.Printf("Balance: %s wei\n", balance.String())
 
-	balanceFloat := new(big.Float).SetInt(balance)
-	divisor := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
-	tokenBalance := new(big.Float).Quo(balanceFloat, divisor)
-	fmt.Printf("Balance: %s tokens\n", tokenBalance.Text('f', 6))
+	for i, q := range queries {
+		balance, err := q.unpack(results[i])
+		if err != nil {
+			return err
+		}
+
+		if q.method == "accountBalances" {
+			fmt.Printf("Payments Contract: %s\n", q.record.Address)
+			fmt.Printf("Balance in Payments: %s wei\n", balance.String())
+			fmt.Printf("Balance in Payments: %s tokens\n", formatTokenBalance(balance))
+			continue
+		}
+
+		fmt.Printf("Token: %s (%s)\n", q.contractName, q.record.Address)
+		fmt.Printf("Balance: %s wei\n", balance.String())
+		fmt.Printf("Balance: %s tokens\n", formatTokenBalance(balance))
+	}
 
 	return nil
 }
 
-func loadWorkspaceConfig() (*WorkspaceConfig, error) {
-	return &WorkspaceConfig{
-		RPC: cfg.RPC,
+// newBalanceQuery prepares the balance lookup of account on contractName. Payments tracks
+// deposited funds per account; tokens use ERC20 balanceOf.
+func newBalanceQuery(deployments []DeploymentRecord, contractName string, account common.Address) (balanceQuery, error) {
+	lookupName, method := contractName, "balanceOf"
+	if strings.EqualFold(contractName, "Payments") {
+		lookupName, method = "Payments", "accountBalances"
+	}
+
+	record, err := findContract(deployments, lookupName)
+	if err != nil {
+		return balanceQuery{}, err
+	}
+
+	parsedABI, err := loadABI(record.ABIPath)
+	if err != nil {
+		return balanceQuery{}, err
+	}
+
+	data, err := parsedABI.Pack(method, account)
+	if err != nil {
+		return balanceQuery{}, fmt.Errorf("failed to pack %s call: %w", method, err)
+	}
+
+	return balanceQuery{
+		contractName: contractName,
+		record:       record,
+		parsedABI:    parsedABI,
+		method:       method,
+		data:         data,
 	}, nil
 }
 
+// unpack decodes the balance returned by the query's call
+func (q balanceQuery) unpack(result []byte) (*big.Int, error) {
+	var balance *big.Int
+	if err := q.parsedABI.UnpackIntoInterface(&balance, q.method, result); err != nil {
+		return nil, fmt.Errorf("failed to unpack balance: %w", err)
+	}
+	return balance, nil
+}
+
+// formatTokenBalance renders an 18-decimal token amount in whole tokens
+func formatTokenBalance(balance *big.Int) string {
+	balanceFloat := new(big.Float).SetInt(balance)
+	divisor := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+	return new(big.Float).Quo(balanceFloat, divisor).Text('f', 6)
+}
+
+// callBalanceQueries runs the lookups at block (nil = latest) through Multicall3 when configured,
+// otherwise one eth_call each
+func callBalanceQueries(ctx context.Context, client *ethclient.Client, multicallAddress string, queries []balanceQuery, block *big.Int) ([][]byte, error) {
+	results := make([][]byte, len(queries))
+
+	if multicallAddress != "" && len(queries) > 1 {
+		calls := make([]config.Call3, len(queries))
+		for i, q := range queries {
+			calls[i] = config.Call3{Target: common.HexToAddress(q.record.Address), CallData: q.data}
+		}
+
+		aggregated, err := config.Aggregate3(ctx, client, common.HexToAddress(multicallAddress), calls, block)
+		if err != nil {
+			return nil, err
+		}
+		for i, r := range aggregated {
+			results[i] = r.ReturnData
+		}
+		return results, nil
+	}
+
+	for i, q := range queries {
+		target := common.HexToAddress(q.record.Address)
+		result, err := client.CallContract(ctx, ethereum.CallMsg{
+			To:   &target,
+			Data: q.data,
+		}, block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to call %s: %w", q.method, err)
+		}
+		results[i] = result
+	}
+
+	return results, nil
+}
+
+// loadWorkspaceConfig returns the connection settings for commands operating on workspace.
+// The RPC and chain ID persisted with `contract workspace set` apply unless --rpc/FILECOIN_RPC is given.
+// loadWorkspaceConfig returns the connection settings for workspace and checks that the node
+// reports the chain ID saved in it
+func loadWorkspaceConfig(ctx context.Context, workspace string) (*WorkspaceConfig, error) {
+	wcfg, err := resolveWorkspaceConfig(workspace)
+	if err != nil {
+		return nil, err
+	}
+	if wcfg.savedChainID {
+		if err := verifyChainID(ctx, wcfg.RPC, wcfg.ChainID); err != nil {
+			return nil, err
+		}
+	}
+	return wcfg, nil
+}
+
+// resolveWorkspaceConfig returns the connection settings for workspace without contacting the node
+func resolveWorkspaceConfig(workspace string) (*WorkspaceConfig, error) {
+	wcfg := &WorkspaceConfig{
+		RPC:        cfg.RPC,
+		Token:      cfg.Token,
+		Multicall3: cfg.Multicall3,
+		ChainID:    config.DefaultChainID,
+	}
+	if workspace == "" {
+		return wcfg, nil
+	}
+
+	settings, err := loadWorkspaceSettings(workspace)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return wcfg, nil
+	}
+
+	if settings.RPC != "" && !rpcOverridden {
+		wcfg.RPC = settings.RPC
+	}
+	if settings.ChainID != 0 {
+		wcfg.ChainID = settings.ChainID
+		wcfg.savedChainID = true
+	}
+	return wcfg, nil
+}
+
 type WorkspaceConfig struct {
-	RPC string
+	RPC        string
+	Token      string
+	Multicall3 string
+	ChainID    int64
+
+	// savedChainID is set when ChainID comes from the workspace settings rather than the default
+	savedChainID bool
 }
 
 func loadDeployments(workspace string) ([]DeploymentRecord, error) {
@@ -890,6 +1520,7 @@ func loadAccounts(workspace string) (*AccountsFile, error) {
 	if err := json.Unmarshal(data, &accounts); err != nil {
 		return nil, err
 	}
+	accounts.normalize()
 
 	return &accounts, nil
 }
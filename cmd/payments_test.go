@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/parthshah1/mpool-tx/config"
+	"github.com/urfave/cli/v2"
+)
+
+// uint256Reply is the ABI encoding of a single uint256 return value
+func uint256Reply(v int64) []byte {
+	return common.BigToHash(big.NewInt(v)).Bytes()
+}
+
+func TestCheckDepositAllowance(t *testing.T) {
+	owner := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	payments := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	token := &DeploymentRecord{Name: "USDFC", Address: "0x3000000000000000000000000000000000000003"}
+
+	tests := []struct {
+		name      string
+		balance   int64
+		allowance int64
+		wantErr   string
+	}{
+		{name: "enough balance and allowance", balance: 100, allowance: 100},
+		{name: "insufficient balance", balance: 99, allowance: 100, wantErr: "insufficient USDFC balance: have 99, need 100"},
+		{name: "insufficient allowance", balance: 100, allowance: 50, wantErr: "insufficient USDFC allowance for Payments: approved 50, need 100 (run `payments approve"},
+		{name: "balance is reported first", balance: 0, allowance: 0, wantErr: "insufficient USDFC balance"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, url := newFakeEthNode(t)
+			node.setCallReply("balanceOf(address)", uint256Reply(tt.balance))
+			node.setCallReply("allowance(address,address)", uint256Reply(tt.allowance))
+
+			client, err := config.DialEthClient(url, "")
+			if err != nil {
+				t.Fatalf("DialEthClient: %v", err)
+			}
+			defer client.Close()
+
+			err = checkDepositAllowance(context.Background(), client, token, owner, payments, big.NewInt(100))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkDepositAllowance: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+const (
+	setupTokenABI    = `[{"inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],"name":"approve","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],"name":"allowance","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`
+	setupPaymentsABI = `[{"inputs":[{"name":"token","type":"address"},{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"name":"deposit","outputs":[],"stateMutability":"payable","type":"function"},{"inputs":[{"name":"token","type":"address"},{"name":"operator","type":"address"},{"name":"approved","type":"bool"},{"name":"rateAllowance","type":"uint256"},{"name":"lockupAllowance","type":"uint256"},{"name":"maxLockupPeriod","type":"uint256"}],"name":"setOperatorApproval","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+)
+
+// setupWorkspace writes the deployments, ABIs, and payer account payments setup reads
+func setupWorkspace(t *testing.T, token, payments, owner common.Address) string {
+	t.Helper()
+
+	workspace := t.TempDir()
+	write := func(name string, v interface{}) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(workspace, name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, contractABI := range map[string]string{"token.abi.json": setupTokenABI, "payments.abi.json": setupPaymentsABI} {
+		if err := os.WriteFile(filepath.Join(workspace, name), []byte(contractABI), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("deployments.json", []DeploymentRecord{
+		{Name: "USDFC", Address: token.Hex(), ABIPath: filepath.Join(workspace, "token.abi.json")},
+		{Name: "Payments", Address: payments.Hex(), ABIPath: filepath.Join(workspace, "payments.abi.json")},
+	})
+	write("accounts.json", AccountsFile{Accounts: map[string]AccountInfo{
+		"client": {EthAddress: owner.Hex(), PrivateKey: testKey},
+	}})
+	return workspace
+}
+
+func TestSetupPayments(t *testing.T) {
+	saved := cfg
+	t.Cleanup(func() { cfg = saved })
+
+	token := common.HexToAddress("0x3000000000000000000000000000000000000003")
+	payments := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	operator := common.HexToAddress("0x4000000000000000000000000000000000000004")
+	_, owner := testSender(t)
+
+	tokenABI, err := abi.JSON(strings.NewReader(setupTokenABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	paymentsABI, err := abi.JSON(strings.NewReader(setupPaymentsABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type call struct {
+		to     common.Address
+		parsed abi.ABI
+		method string
+		args   []interface{}
+	}
+	var (
+		amount        = big.NewInt(500)
+		approve       = call{token, tokenABI, "approve", []interface{}{payments, amount}}
+		deposit       = call{payments, paymentsABI, "deposit", []interface{}{token, owner, amount}}
+		setOperator   = call{payments, paymentsABI, "setOperatorApproval", []interface{}{token, operator, true, big.NewInt(10), big.NewInt(20), big.NewInt(30)}}
+		resetApproval = call{token, tokenABI, "approve", []interface{}{payments, big.NewInt(0)}}
+	)
+
+	tests := []struct {
+		name      string
+		allowance int64
+		fail      string
+		wantCalls []call
+		wantErr   string
+	}{
+		{
+			name:      "all steps in order",
+			allowance: 500,
+			wantCalls: []call{approve, deposit, setOperator},
+		},
+		{
+			name:      "deposit precheck fails and the approval is reset",
+			allowance: 100,
+			wantCalls: []call{approve, resetApproval},
+			wantErr:   "payments setup failed at step 2",
+		},
+		{
+			name:      "operator approval fails after the deposit",
+			allowance: 500,
+			fail:      "setOperatorApproval(address,address,bool,uint256,uint256,uint256)",
+			wantCalls: []call{approve, deposit, resetApproval},
+			wantErr:   "payments setup failed at step 3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, url := newFakeEthNode(t)
+			node.setCallReply("balanceOf(address)", uint256Reply(1000))
+			node.setCallReply("allowance(address,address)", uint256Reply(tt.allowance))
+			if tt.fail != "" {
+				node.failMethod(tt.fail)
+			}
+			cfg = &config.Config{RPC: url, ContractTimeout: time.Minute}
+
+			var setup *cli.Command
+			for _, sub := range PaymentsCmd.Subcommands {
+				if sub.Name == "setup" {
+					setup = sub
+				}
+			}
+			if setup == nil {
+				t.Fatal("payments setup command not found")
+			}
+
+			app := &cli.App{Commands: []*cli.Command{setup}}
+			err := app.Run([]string{"filwizard", "setup",
+				"--workspace", setupWorkspace(t, token, payments, owner),
+				"--token", "USDFC", "--amount", "500", "--operator", operator.Hex(),
+				"--rate-allowance", "10", "--lockup-allowance", "20", "--max-lockup-period", "30",
+				"--from", "client",
+			})
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("setup: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+			}
+
+			sent := node.transactions()
+			if len(sent) != len(tt.wantCalls) {
+				t.Fatalf("sent %d transactions, want %d", len(sent), len(tt.wantCalls))
+			}
+			for i, want := range tt.wantCalls {
+				data, err := want.parsed.Pack(want.method, want.args...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if *sent[i].To() != want.to || !bytes.Equal(sent[i].Data(), data) {
+					t.Errorf("transaction %d is not %s%v to %s", i, want.method, want.args, want.to.Hex())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/parthshah1/mpool-tx/config"
+)
+
+// DefaultDeployGas is the per-contract gas budget assumed when a deployment cannot be estimated
+const DefaultDeployGas = 250_000_000
+
+// preflightMarginPercent is added on top of the estimated cost to absorb fee movement
+const preflightMarginPercent = 20
+
+// preflightDeployer estimates the cost of deploying contracts and checks the deployer can pay for it.
+// When topUp is set, a shortfall is covered from the node's default wallet; otherwise it is an error.
+func preflightDeployer(ctx context.Context, rpcURL, deployerKey, workspace string, contracts []config.ContractConfig, fallbackGas uint64, topUp bool) error {
+	if len(contracts) == 0 {
+		return nil
+	}
+
+	deployer, err := ethAddressFromPrivateKey(deployerKey)
+	if err != nil {
+		return fmt.Errorf("invalid deployer key: %w", err)
+	}
+	from := common.BytesToAddress(deployer[:])
+
+	client, err := config.DialEthClient(rpcURL, cfg.Token)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", rpcURL, err)
+	}
+	defer client.Close()
+
+	var totalGas uint64
+	for _, cdef := range contracts {
+		totalGas += estimateDeployGas(ctx, client, from, workspace, cdef, fallbackGas)
+	}
+
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	required := new(big.Int).Mul(new(big.Int).SetUint64(totalGas), gasPrice)
+	required.Mul(required, big.NewInt(100+preflightMarginPercent))
+	required.Div(required, big.NewInt(100))
+
+	balance, err := client.BalanceAt(ctx, from, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get deployer balance: %w", err)
+	}
+
+	fmt.Printf("Preflight: %d deployment(s), ~%d gas at %s attoFIL/gas, need %s (deployer %s has %s)\n",
+		len(contracts), totalGas, gasPrice, types.FIL(types.BigInt{Int: required}), from.Hex(), types.FIL(types.BigInt{Int: balance}))
+
+	if balance.Cmp(required) >= 0 {
+		return nil
+	}
+
+	shortfall := new(big.Int).Sub(required, balance)
+	if !topUp {
+		return fmt.Errorf("deployer %s is underfunded by %s for the planned deployments (fund it, or rerun with --topup)", from.Hex(), types.FIL(types.BigInt{Int: shortfall}))
+	}
+
+	filAddr, err := deployer.ToFilecoinAddress()
+	if err != nil {
+		return fmt.Errorf("failed to convert deployer address: %w", err)
+	}
+
+	fmt.Printf("Topping up deployer with %s...\n", types.FIL(types.BigInt{Int: shortfall}))
+	if _, err := FundWallet(ctx, filAddr, types.BigInt{Int: shortfall}, true); err != nil {
+		return fmt.Errorf("failed to top up deployer: %w", err)
+	}
+	return nil
+}
+
+// estimateDeployGas estimates a contract's creation gas from its forge artifact when it has
+// no constructor args, falling back to the fixed budget otherwise
+func estimateDeployGas(ctx context.Context, client *ethclient.Client, from common.Address, workspace string, cdef config.ContractConfig, fallbackGas uint64) uint64 {
+	if cdef.DeployScript != "" || len(cdef.ConstructorArgs) > 0 || cdef.ContractPath == "" {
+		return fallbackGas
+	}
+
+	cloneDir := filepath.Join(workspace, strings.ReplaceAll(strings.ToLower(cdef.Name), " ", "-"))
+	artifact, err := loadForgeArtifact(cloneDir, cdef.ContractPath, cdef.MainContract)
+	if err != nil || artifact.Bytecode.Object == "" {
+		return fallbackGas
+	}
+
+	gas, err := client.EstimateGas(ctx, ethereum.CallMsg{
+		From: from,
+		Data: common.FromHex(artifact.Bytecode.Object),
+	})
+	if err != nil {
+		return fallbackGas
+	}
+	return gas
+}
@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/parthshah1/mpool-tx/config"
 	"github.com/urfave/cli/v2"
@@ -11,6 +14,12 @@ import (
 var (
 	cfg     *config.Config
 	clientt *config.Client
+
+	// cancelRoot releases the root context created in Before
+	cancelRoot context.CancelFunc
+
+	// rpcOverridden is set when --rpc or FILECOIN_RPC was given, taking precedence over a workspace RPC
+	rpcOverridden bool
 )
 
 // NewApp creates a new CLI app
@@ -29,38 +38,106 @@ func NewApp() *cli.App {
 				Usage:   "JWT token file path (env: FILECOIN_TOKEN)",
 				EnvVars: []string{"FILECOIN_TOKEN"},
 			},
+			&cli.StringFlag{
+				Name:    "multicall3",
+				Usage:   "Multicall3 contract address for aggregated reads (env: MULTICALL3_ADDRESS)",
+				EnvVars: []string{"MULTICALL3_ADDRESS"},
+			},
+			&cli.Uint64Flag{
+				Name:    "confirmations",
+				Usage:   "Epochs to wait after a message is included before treating it as confirmed (env: FILWIZARD_CONFIRMATIONS)",
+				EnvVars: []string{"FILWIZARD_CONFIRMATIONS"},
+			},
+			&cli.IntFlag{
+				Name:    "rpc-concurrency",
+				Usage:   "Maximum simultaneous RPC requests, for rate-limited endpoints (0 = unlimited) (env: FILWIZARD_RPC_CONCURRENCY)",
+				EnvVars: []string{"FILWIZARD_RPC_CONCURRENCY"},
+			},
+			&cli.DurationFlag{
+				Name:    "receipt-timeout",
+				Usage:   "How long to wait for a transaction receipt before giving up (env: FILWIZARD_RECEIPT_TIMEOUT)",
+				EnvVars: []string{"FILWIZARD_RECEIPT_TIMEOUT"},
+			},
+			&cli.DurationFlag{
+				Name:    "timeout",
+				Usage:   "Abort the command after this duration, e.g. 10m (0 = no limit) (env: FILWIZARD_TIMEOUT)",
+				EnvVars: []string{"FILWIZARD_TIMEOUT"},
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Usage:   "Output format for list/info/status commands: text or json (env: FILWIZARD_OUTPUT)",
+				EnvVars: []string{"FILWIZARD_OUTPUT"},
+			},
 			&cli.BoolFlag{
 				Name:    "verbose",
 				Usage:   "Verbose output (env: VERBOSE)",
 				EnvVars: []string{"VERBOSE"},
 			},
 		},
+		Version: GetBuildInfo().Version,
 		Before: func(c *cli.Context) error {
 			cfg = config.Load()
 
 			if c.IsSet("rpc") {
 				cfg.RPC = c.String("rpc")
 			}
+			rpcOverridden = c.IsSet("rpc")
 			if c.IsSet("token") {
 				cfg.Token = c.String("token")
 			}
+			if c.IsSet("multicall3") {
+				cfg.Multicall3 = c.String("multicall3")
+			}
 			if c.IsSet("verbose") {
 				cfg.Verbose = c.Bool("verbose")
 			}
+			if c.IsSet("confirmations") {
+				cfg.Confirmations = c.Uint64("confirmations")
+			}
+			if c.IsSet("rpc-concurrency") {
+				cfg.RPCConcurrency = c.Int("rpc-concurrency")
+			}
+			if c.IsSet("receipt-timeout") {
+				cfg.ReceiptTimeout = c.Duration("receipt-timeout")
+			}
+			if c.IsSet("output") {
+				cfg.Output = c.String("output")
+			}
+			if cfg.Output != config.OutputText && cfg.Output != config.OutputJSON {
+				return fmt.Errorf("invalid --output %q: expected text or json", cfg.Output)
+			}
+			config.SetRPCConcurrency(cfg.RPCConcurrency)
+			config.SetReceiptTimeout(cfg.ReceiptTimeout)
 
-			// Initialize client
-			var err error
-			clientt, err = config.New(cfg)
-			if err != nil {
-				return fmt.Errorf("failed to connect to Filecoin node: %w", err)
+			// Root context shared by all commands: cancelled on Ctrl+C/SIGTERM and bounded by --timeout
+			ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+			cancel := context.CancelFunc(stop)
+			if timeout := c.Duration("timeout"); timeout > 0 {
+				var cancelTimeout context.CancelFunc
+				ctx, cancelTimeout = context.WithTimeout(ctx, timeout)
+				cancel = func() {
+					cancelTimeout()
+					stop()
+				}
 			}
+			// Restore default signal handling once cancelled so a second Ctrl+C exits immediately
+			go func() {
+				<-ctx.Done()
+				stop()
+			}()
+			c.Context = ctx
+			cancelRoot = cancel
 
+			// The node client is dialed by each command once its --workspace is known
 			return nil
 		},
 		After: func(c *cli.Context) error {
 			if clientt != nil {
 				clientt.Close()
 			}
+			if cancelRoot != nil {
+				cancelRoot()
+			}
 			return nil
 		},
 		Commands: []*cli.Command{
@@ -68,11 +145,52 @@ func NewApp() *cli.App {
 			ContractCmd,
 			AccountsCmd,
 			PaymentsCmd,
+			MempoolCmd,
+			VersionCmd,
+			DoctorCmd,
 		},
 	}
+	connectClients(app.Commands)
 	return app
 }
 
+// connectClients gives every command that talks to the node a Before hook that dials it
+func connectClients(cmds []*cli.Command) {
+	for _, cmd := range cmds {
+		switch {
+		case cmd == VersionCmd, cmd == DoctorCmd, cmd == workspaceCmd:
+			// Build information needs no node connection, doctor reports connection failures
+			// itself, and workspace set/show manage the saved RPC rather than use it
+		case len(cmd.Subcommands) > 0:
+			connectClients(cmd.Subcommands)
+		default:
+			cmd.Before = connectClient
+		}
+	}
+}
+
+// connectClient dials the Lotus client once the command's flags are parsed, using the RPC saved
+// in its --workspace unless --rpc/FILECOIN_RPC was given, so every client of the command talks
+// to the same node
+func connectClient(c *cli.Context) error {
+	if workspace := c.String("workspace"); workspace != "" {
+		settings, err := loadWorkspaceSettings(workspace)
+		if err != nil {
+			return err
+		}
+		if settings != nil && settings.RPC != "" && !rpcOverridden {
+			cfg.RPC = settings.RPC
+		}
+	}
+
+	var err error
+	clientt, err = config.New(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Filecoin node: %w", err)
+	}
+	return nil
+}
+
 func Execute() {
 	if err := NewApp().Run(os.Args); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -81,5 +199,7 @@ func Execute() {
 }
 
 func init() {
-	// No initialization needed for urfave/cli
+	cli.VersionPrinter = func(c *cli.Context) {
+		printVersion(c.App.Writer)
+	}
 }
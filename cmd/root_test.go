@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/parthshah1/mpool-tx/config"
+	"github.com/urfave/cli/v2"
+)
+
+// fakeLotusNode answers Filecoin.Version and counts the requests it receives
+type fakeLotusNode struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (n *fakeLotusNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID json.RawMessage `json:"id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	n.mu.Lock()
+	n.calls++
+	n.mu.Unlock()
+
+	version := map[string]interface{}{"Version": "test", "APIVersion": 0, "BlockDelay": 30}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": version})
+}
+
+func (n *fakeLotusNode) requests() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.calls
+}
+
+func TestConnectClientUsesWorkspaceRPC(t *testing.T) {
+	savedCfg, savedClient, savedOverridden := cfg, clientt, rpcOverridden
+	t.Cleanup(func() { cfg, clientt, rpcOverridden = savedCfg, savedClient, savedOverridden })
+
+	tests := []struct {
+		name          string
+		rpcOverridden bool
+		wantWorkspace bool
+	}{
+		{name: "saved RPC", wantWorkspace: true},
+		{name: "--rpc takes precedence", rpcOverridden: true, wantWorkspace: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			global := &fakeLotusNode{}
+			globalServer := httptest.NewServer(global)
+			defer globalServer.Close()
+			saved := &fakeLotusNode{}
+			savedServer := httptest.NewServer(saved)
+			defer savedServer.Close()
+
+			workspace := testWorkspace(t, &WorkspaceSettings{RPC: savedServer.URL})
+			cfg = &config.Config{RPC: globalServer.URL}
+			rpcOverridden = tt.rpcOverridden
+
+			// The client is used the way FundWallet and the mempool commands use it
+			app := &cli.App{
+				Commands: []*cli.Command{{
+					Name:   "status",
+					Flags:  []cli.Flag{&cli.StringFlag{Name: "workspace"}},
+					Before: connectClient,
+					Action: func(c *cli.Context) error {
+						defer clientt.Close()
+						_, err := clientt.GetAPI().Version(c.Context)
+						return err
+					},
+				}},
+			}
+			if err := app.Run([]string{"filwizard", "status", "--workspace", workspace}); err != nil {
+				t.Fatalf("run: %v", err)
+			}
+
+			want, other := global, saved
+			if tt.wantWorkspace {
+				want, other = saved, global
+			}
+			if want.requests() == 0 {
+				t.Error("the expected node received no requests")
+			}
+			if n := other.requests(); n != 0 {
+				t.Errorf("the other node received %d request(s), want 0", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// maxSuggestions caps how many close names an unknown-method error lists
+const maxSuggestions = 3
+
+// checkMethodName verifies that method exists in the ABI of the deployed contract nameOrAddress.
+// Contracts without a recorded ABI (including raw addresses) are not checked. An unknown name
+// fails with the closest method names as suggestions, rather than sending a call to a selector
+// the contract does not implement.
+func checkMethodName(deployments []DeploymentRecord, nameOrAddress, method string) error {
+	record, err := findContractIgnoreCase(deployments, nameOrAddress)
+	if err != nil || record.ABIPath == "" {
+		return nil
+	}
+
+	parsedABI, err := loadABI(record.ABIPath)
+	if err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(parsedABI.Methods))
+	seen := make(map[string]bool)
+	for _, m := range parsedABI.Methods {
+		if m.RawName == method {
+			return nil
+		}
+		if !seen[m.RawName] {
+			seen[m.RawName] = true
+			names = append(names, m.RawName)
+		}
+	}
+
+	msg := fmt.Sprintf("method %s not found in %s ABI", method, record.Name)
+	if suggestions := closestNames(method, names); len(suggestions) > 0 {
+		msg += fmt.Sprintf(" (did you mean %s?)", strings.Join(suggestions, ", "))
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// warnMethodMutability warns when read is used on a method that changes state, where eth_call
+// only simulates it, or when write sends a transaction to a view/pure method. Like
+// checkMethodName it needs the deployed ABI; overloads only warn if every variant agrees.
+// Warnings go to stderr so --json output stays parseable.
+func warnMethodMutability(deployments []DeploymentRecord, nameOrAddress, method string, write bool) {
+	record, err := findContractIgnoreCase(deployments, nameOrAddress)
+	if err != nil || record.ABIPath == "" {
+		return
+	}
+
+	parsedABI, err := loadABI(record.ABIPath)
+	if err != nil {
+		return
+	}
+
+	var found, views int
+	for _, m := range parsedABI.Methods {
+		if m.RawName != method {
+			continue
+		}
+		found++
+		if m.IsConstant() {
+			views++
+		}
+	}
+
+	switch {
+	case found == 0:
+		return
+	case !write && views == 0:
+		fmt.Fprintf(os.Stderr, "Warning: %s.%s is not a view function; read only simulates it with eth_call and no state changes (use write to send a transaction)\n", record.Name, method)
+	case write && views == found:
+		fmt.Fprintf(os.Stderr, "Warning: %s.%s is a view function; sending a transaction changes nothing (use read to get its result)\n", record.Name, method)
+	}
+}
+
+// closestNames returns up to maxSuggestions candidates within a small edit distance of name,
+// closest first
+func closestNames(name string, candidates []string) []string {
+	type scored struct {
+		name     string
+		distance int
+	}
+
+	limit := max(2, len(name)/3)
+	var matches []scored
+	for _, candidate := range candidates {
+		d := levenshtein(strings.ToLower(name), strings.ToLower(candidate))
+		if d <= limit {
+			matches = append(matches, scored{candidate, d})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	out := make([]string, 0, maxSuggestions)
+	for i := 0; i < len(matches) && i < maxSuggestions; i++ {
+		out = append(out, matches[i].name)
+	}
+	return out
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testTokenABI is a small ERC-20 style ABI with an admin method, for name and policy checks
+const testTokenABI = `[
+  {"type": "function", "name": "transfer", "stateMutability": "nonpayable",
+   "inputs": [{"name": "to", "type": "address"}, {"name": "amount", "type": "uint256"}],
+   "outputs": [{"name": "", "type": "bool"}]},
+  {"type": "function", "name": "balanceOf", "stateMutability": "view",
+   "inputs": [{"name": "owner", "type": "address"}],
+   "outputs": [{"name": "", "type": "uint256"}]},
+  {"type": "function", "name": "upgradeTo", "stateMutability": "nonpayable",
+   "inputs": [{"name": "implementation", "type": "address"}],
+   "outputs": []}
+]`
+
+// testTokenAddress is where the test Token is recorded as deployed
+const testTokenAddress = "0x00000000000000000000000000000000000000aa"
+
+// writeTestDeployments writes testTokenABI to a temp dir and returns a deployments list with Token
+func writeTestDeployments(t *testing.T) []DeploymentRecord {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "Token.abi.json")
+	if err := os.WriteFile(path, []byte(testTokenABI), 0644); err != nil {
+		t.Fatalf("failed to write ABI: %v", err)
+	}
+	return []DeploymentRecord{{Name: "Token", Address: testTokenAddress, ABIPath: path}}
+}
+
+func TestCheckMethodName(t *testing.T) {
+	deployments := writeTestDeployments(t)
+
+	tests := []struct {
+		name     string
+		contract string
+		method   string
+		wantErr  string
+	}{
+		{name: "known method", contract: "Token", method: "transfer"},
+		{name: "contract name is case-insensitive", contract: "token", method: "balanceOf"},
+		{name: "typo gets a suggestion", contract: "Token", method: "balnceOf", wantErr: "did you mean balanceOf?"},
+		{name: "unrelated name has no suggestion", contract: "Token", method: "mintEverything", wantErr: "method mintEverything not found in Token ABI"},
+		{name: "unknown contract is not checked", contract: "Other", method: "anything"},
+		{name: "raw address is not checked", contract: testTokenAddress, method: "anything"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkMethodName(deployments, tt.contract, tt.method)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkMethodName: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+			}
+			if strings.Contains(tt.wantErr, "not found") && strings.Contains(err.Error(), "did you mean") {
+				t.Errorf("error %q suggests a name for an unrelated method", err)
+			}
+		})
+	}
+}
+
+// captureStderr returns what fn writes to stderr
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe: %v", err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = stderr }()
+
+	fn()
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("failed to read stderr: %v", err)
+	}
+	return string(out)
+}
+
+func TestWarnMethodMutability(t *testing.T) {
+	deployments := writeTestDeployments(t)
+
+	tests := []struct {
+		name     string
+		method   string
+		write    bool
+		wantWarn string
+	}{
+		{name: "read of a view", method: "balanceOf", write: false},
+		{name: "write of a non-view", method: "transfer", write: true},
+		{name: "read of a non-view", method: "transfer", write: false, wantWarn: "Token.transfer is not a view function"},
+		{name: "write of a view", method: "balanceOf", write: true, wantWarn: "Token.balanceOf is a view function"},
+		{name: "unknown method", method: "mint", write: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := captureStderr(t, func() {
+				warnMethodMutability(deployments, "Token", tt.method, tt.write)
+			})
+			if tt.wantWarn == "" {
+				if out != "" {
+					t.Errorf("unexpected warning: %s", out)
+				}
+				return
+			}
+			if !strings.Contains(out, tt.wantWarn) {
+				t.Errorf("warning = %q, want one containing %q", out, tt.wantWarn)
+			}
+		})
+	}
+}
+
+func TestClosestNames(t *testing.T) {
+	candidates := []string{"transfer", "transferFrom", "approve", "allowance", "balanceOf"}
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{name: "transfr", want: []string{"transfer"}},
+		{name: "TRANSFER", want: []string{"transfer"}},
+		{name: "aprove", want: []string{"approve"}},
+		{name: "totallyDifferent", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := closestNames(tt.name, candidates); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("closestNames(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"balanceOf", "balnceOf", 1},
+		{"transfer", "transfer", 0},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
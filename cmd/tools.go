@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/parthshah1/mpool-tx/config"
+)
+
+// toolInstallHints describes how to install each external binary the CLI shells out to
+var toolInstallHints = map[string]string{
+	"abigen": "go install github.com/ethereum/go-ethereum/cmd/abigen@latest",
+	"solc":   "see https://docs.soliditylang.org/en/latest/installing-solidity.html (or `npm install -g solc`)",
+	"forge":  "install Foundry: curl -L https://foundry.paradigm.xyz | bash && foundryup",
+	"cast":   "install Foundry: curl -L https://foundry.paradigm.xyz | bash && foundryup",
+	"git":    "install git from https://git-scm.com/downloads",
+	"yarn":   "npm install -g yarn",
+}
+
+// RequireTools checks that every named binary is on PATH and returns a single error
+// listing all missing tools with install hints
+func RequireTools(tools ...string) error {
+	seen := make(map[string]bool)
+	var missing []string
+	for _, tool := range tools {
+		if seen[tool] {
+			continue
+		}
+		seen[tool] = true
+		if _, err := exec.LookPath(tool); err != nil {
+			missing = append(missing, tool)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "missing required tool(s): %s", strings.Join(missing, ", "))
+	for _, tool := range missing {
+		if hint, ok := toolInstallHints[tool]; ok {
+			fmt.Fprintf(&b, "\n  %s: %s", tool, hint)
+		}
+	}
+	return fmt.Errorf("%s", b.String())
+}
+
+// deployLocalTools returns the binaries deploy-local needs for the selected contracts
+func deployLocalTools(contracts []config.ContractConfig, generateBindings, compile bool) []string {
+	var tools []string
+	if compile {
+		tools = append(tools, "forge")
+	}
+	for _, cdef := range contracts {
+		if cdef.DeployScript != "" {
+			// Custom scripts declare their own tooling
+			continue
+		}
+		tools = append(tools, "forge")
+		if generateBindings || cdef.GenerateBindings {
+			tools = append(tools, "abigen")
+		}
+	}
+	return tools
+}
+
+// gitDeployTools returns the binaries from-git needs to clone, build, and deploy a project
+func gitDeployTools(projectType ProjectType, generateBindings bool) []string {
+	tools := []string{"git", "forge"}
+	if projectType == ProjectTypeHardhat {
+		tools = append(tools, "yarn")
+	}
+	if generateBindings {
+		tools = append(tools, "abigen")
+	}
+	return tools
+}
@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/filecoin-project/lotus/chain/types/ethtypes"
+	"github.com/parthshah1/mpool-tx/config"
+	"github.com/urfave/cli/v2"
+)
+
+// forgeArtifact is the subset of a forge build artifact (out/<File>.sol/<Contract>.json) the CLI reads
+type forgeArtifact struct {
+	Bytecode struct {
+		Object string `json:"object"`
+	} `json:"bytecode"`
+	Metadata struct {
+		Compiler struct {
+			Version string `json:"version"`
+		} `json:"compiler"`
+	} `json:"metadata"`
+}
+
+// loadForgeArtifact reads the build artifact for contractName compiled from contractPath in projectDir
+func loadForgeArtifact(projectDir, contractPath, contractName string) (*forgeArtifact, error) {
+	artifactPath := filepath.Join(projectDir, "out", filepath.Base(contractPath), contractName+".json")
+	data, err := os.ReadFile(artifactPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read forge artifact: %w", err)
+	}
+
+	var artifact forgeArtifact
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		return nil, fmt.Errorf("failed to parse forge artifact %s: %w", artifactPath, err)
+	}
+	return &artifact, nil
+}
+
+// explorerResponse is the envelope returned by Etherscan-compatible explorer APIs (Blockscout, Filfox, ...)
+type explorerResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Result  string `json:"result"`
+}
+
+func verifyContract(c *cli.Context) error {
+	ctx := c.Context
+	workspace := c.String("workspace")
+	name := c.String("name")
+	apiURL := strings.TrimRight(c.String("explorer-url"), "/")
+
+	manager := NewContractManager(workspace, c.String("rpc-url"))
+	deployment, err := manager.GetDeployment(name)
+	if err != nil {
+		return fmt.Errorf("failed to find deployment: %w", err)
+	}
+
+	contractID := c.String("contract")
+	if contractID == "" {
+		contractID, err = contractIDFromConfig(c.String("config"), name)
+		if err != nil {
+			return err
+		}
+	}
+	contractPath, contractName, ok := strings.Cut(contractID, ":")
+	if !ok || contractPath == "" || contractName == "" {
+		return fmt.Errorf("invalid --contract %q: expected path/to/Contract.sol:ContractName", contractID)
+	}
+
+	projectDir := c.String("project-dir")
+	if projectDir == "" {
+		projectDir = filepath.Join(workspace, strings.ReplaceAll(strings.ToLower(name), " ", "-"))
+	}
+	absProjectDir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path for %s: %w", projectDir, err)
+	}
+
+	if err := RequireTools("forge"); err != nil {
+		return err
+	}
+
+	artifact, err := loadForgeArtifact(absProjectDir, contractPath, contractName)
+	if err != nil {
+		return err
+	}
+	if artifact.Metadata.Compiler.Version == "" {
+		return fmt.Errorf("forge artifact for %s has no compiler version; rebuild with metadata enabled", contractName)
+	}
+
+	address := config.ChecksumAddress(deployment.Address.String())
+	standardJSON, err := forgeStandardJSONInput(absProjectDir, address, contractID)
+	if err != nil {
+		return err
+	}
+
+	constructorArgs := strings.TrimPrefix(c.String("constructor-args"), "0x")
+	if !c.IsSet("constructor-args") {
+		constructorArgs, err = deployedConstructorArgs(ctx, c.String("rpc-url"), deployment, artifact.Bytecode.Object)
+		if err != nil {
+			fmt.Printf("Warning: %v; submitting without constructor args (use --constructor-args to set them)\n", err)
+		}
+	}
+
+	form := url.Values{}
+	form.Set("module", "contract")
+	form.Set("action", "verifysourcecode")
+	form.Set("apikey", c.String("api-key"))
+	form.Set("contractaddress", address)
+	form.Set("contractname", contractID)
+	form.Set("codeformat", "solidity-standard-json-input")
+	form.Set("sourceCode", standardJSON)
+	form.Set("compilerversion", "v"+strings.TrimPrefix(artifact.Metadata.Compiler.Version, "v"))
+	// The misspelling is part of the Etherscan API
+	form.Set("constructorArguements", constructorArgs)
+
+	fmt.Printf("Submitting %s (%s) for verification to %s...\n", name, address, apiURL)
+	var submitted explorerResponse
+	if err := postExplorerForm(ctx, apiURL, form, &submitted); err != nil {
+		return fmt.Errorf("failed to submit verification: %w", err)
+	}
+	if submitted.Status != "1" {
+		if strings.Contains(strings.ToLower(submitted.Result), "already verified") {
+			fmt.Printf("Contract %s is already verified\n", name)
+			return nil
+		}
+		return fmt.Errorf("verification rejected: %s: %s", submitted.Message, submitted.Result)
+	}
+
+	guid := submitted.Result
+	fmt.Printf("Submitted, GUID: %s\n", guid)
+	return pollVerification(ctx, apiURL, c.String("api-key"), guid, c.Duration("poll-interval"))
+}
+
+// contractIDFromConfig looks up a contract's path:Name identifier in the contracts config
+func contractIDFromConfig(configPath, name string) (string, error) {
+	contractsConfig, err := config.LoadContractsConfig(configPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to load config (or pass --contract): %w", err)
+	}
+	for _, cdef := range contractsConfig.Contracts {
+		if strings.EqualFold(cdef.Name, name) {
+			if cdef.ContractPath == "" || cdef.MainContract == "" {
+				return "", fmt.Errorf("contract %s has no contract_path/main_contract in %s; pass --contract", name, configPath)
+			}
+			return fmt.Sprintf("%s:%s", cdef.ContractPath, cdef.MainContract), nil
+		}
+	}
+	return "", fmt.Errorf("contract %s not found in %s; pass --contract", name, configPath)
+}
+
+// forgeStandardJSONInput asks forge for the standard-json compiler input of a deployed contract
+func forgeStandardJSONInput(projectDir, address, contractID string) (string, error) {
+	cmd := exec.Command("forge", "verify-contract", "--show-standard-json-input", address, contractID)
+	cmd.Dir = projectDir
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("failed to build standard-json input: %w, output: %s", err, string(exitErr.Stderr))
+		}
+		return "", fmt.Errorf("failed to build standard-json input: %w", err)
+	}
+	return string(output), nil
+}
+
+// deployedConstructorArgs recovers the ABI-encoded constructor args from the deployment
+// transaction by stripping the creation bytecode from its input
+func deployedConstructorArgs(ctx context.Context, rpcURL string, deployment *DeployedContract, creationCode string) (string, error) {
+	if deployment.TransactionHash == (ethtypes.EthHash{}) {
+		return "", fmt.Errorf("deployment record has no transaction hash")
+	}
+
+	client, err := config.DialEthClient(rpcURL, cfg.Token)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to %s: %w", rpcURL, err)
+	}
+	defer client.Close()
+
+	tx, _, err := client.TransactionByHash(ctx, common.HexToHash(deployment.TransactionHash.String()))
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch deployment transaction: %w", err)
+	}
+
+	input := hex.EncodeToString(tx.Data())
+	code := strings.ToLower(strings.TrimPrefix(creationCode, "0x"))
+	if code == "" || !strings.HasPrefix(input, code) {
+		return "", fmt.Errorf("deployment transaction does not start with the artifact bytecode")
+	}
+	return input[len(code):], nil
+}
+
+// postExplorerForm posts a form to an Etherscan-compatible API and decodes the response envelope
+func postExplorerForm(ctx context.Context, apiURL string, form url.Values, out *explorerResponse) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return doExplorerRequest(req, out)
+}
+
+func doExplorerRequest(req *http.Request, out *explorerResponse) error {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("explorer returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse explorer response: %w", err)
+	}
+	return nil
+}
+
+// pollVerification checks a submitted verification until it passes, fails, or ctx is done
+func pollVerification(ctx context.Context, apiURL, apiKey, guid string, interval time.Duration) error {
+	query := url.Values{}
+	query.Set("module", "contract")
+	query.Set("action", "checkverifystatus")
+	query.Set("guid", guid)
+	query.Set("apikey", apiKey)
+
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+"?"+query.Encode(), nil)
+		if err != nil {
+			return err
+		}
+
+		var status explorerResponse
+		if err := doExplorerRequest(req, &status); err != nil {
+			return fmt.Errorf("failed to check verification status: %w", err)
+		}
+
+		result := strings.ToLower(status.Result)
+		switch {
+		case strings.Contains(result, "pending"):
+			fmt.Printf("  %s\n", status.Result)
+		case status.Status == "1" || strings.Contains(result, "already verified"):
+			fmt.Printf("Verification succeeded: %s\n", status.Result)
+			return nil
+		default:
+			return fmt.Errorf("verification failed: %s", status.Result)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
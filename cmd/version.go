@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/parthshah1/mpool-tx/config"
+	"github.com/urfave/cli/v2"
+)
+
+// Build information, set at build time via:
+//
+//	-ldflags "-X github.com/parthshah1/mpool-tx/cmd.Version=... -X github.com/parthshah1/mpool-tx/cmd.Commit=... -X github.com/parthshah1/mpool-tx/cmd.BuildDate=..."
+var (
+	Version   = ""
+	Commit    = ""
+	BuildDate = ""
+)
+
+// BuildInfo describes the running binary
+type BuildInfo struct {
+	Version   string
+	Commit    string
+	BuildDate string
+	GoVersion string
+}
+
+// GetBuildInfo returns the ldflags build information, falling back to the module
+// version and VCS revision recorded by the Go toolchain when they are unset
+func GetBuildInfo() BuildInfo {
+	info := BuildInfo{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		if info.Version == "" && bi.Main.Version != "" {
+			info.Version = bi.Main.Version
+		}
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = s.Value
+				}
+			case "vcs.time":
+				if info.BuildDate == "" {
+					info.BuildDate = s.Value
+				}
+			}
+		}
+	}
+
+	if info.Version == "" {
+		info.Version = "(devel)"
+	}
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.BuildDate == "" {
+		info.BuildDate = "unknown"
+	}
+
+	return info
+}
+
+func printVersion(w io.Writer) {
+	info := GetBuildInfo()
+	defaults := config.Load()
+
+	fmt.Fprintf(w, "filwizard %s\n", info.Version)
+	fmt.Fprintf(w, "Commit: %s\n", info.Commit)
+	fmt.Fprintf(w, "Built: %s\n", info.BuildDate)
+	fmt.Fprintf(w, "Go: %s\n", info.GoVersion)
+	fmt.Fprintf(w, "Default RPC: %s\n", defaults.RPC)
+	fmt.Fprintf(w, "Assumed chain ID: %d\n", config.DefaultChainID)
+}
+
+var VersionCmd = &cli.Command{
+	Name:  "version",
+	Usage: "Print build information and default network assumptions",
+	Action: func(c *cli.Context) error {
+		printVersion(c.App.Writer)
+		return nil
+	},
+}
@@ -8,18 +8,23 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/lotus/api"
 	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/chain/types/ethtypes"
 	"github.com/filecoin-project/lotus/chain/wallet/key"
 	_ "github.com/filecoin-project/lotus/lib/sigs/delegated"
 	_ "github.com/filecoin-project/lotus/lib/sigs/secp"
+	"github.com/ipfs/go-cid"
+	"github.com/parthshah1/mpool-tx/config"
 
 	"github.com/urfave/cli/v2"
 )
@@ -33,6 +38,15 @@ func CreateWallet(ctx context.Context, keyType types.KeyType) (address.Address,
 	return addr, nil
 }
 
+// ImportWallet imports a key into the node's wallet and returns its address
+func ImportWallet(ctx context.Context, k *key.Key) (address.Address, error) {
+	addr, err := clientt.GetAPI().WalletImport(ctx, &k.KeyInfo)
+	if err != nil {
+		return address.Undef, fmt.Errorf("failed to import wallet: %w", err)
+	}
+	return addr, nil
+}
+
 // ListWallets returns all wallets
 func ListWallets(ctx context.Context) ([]address.Address, error) {
 	addrs, err := clientt.GetAPI().WalletList(ctx)
@@ -49,6 +63,11 @@ func NewAccount() (*key.Key, ethtypes.EthAddress, address.Address, error) {
 		return nil, ethtypes.EthAddress{}, address.Address{}, fmt.Errorf("failed to generate key: %w", err)
 	}
 
+	return accountFromKey(key)
+}
+
+// accountFromKey derives the Ethereum and Filecoin addresses for a secp256k1 key
+func accountFromKey(key *key.Key) (*key.Key, ethtypes.EthAddress, address.Address, error) {
 	ethAddr, err := ethtypes.EthAddressFromPubKey(key.PublicKey)
 	if err != nil {
 		return nil, ethtypes.EthAddress{}, address.Address{}, fmt.Errorf("failed to generate Ethereum address: %w", err)
@@ -66,6 +85,14 @@ func NewAccount() (*key.Key, ethtypes.EthAddress, address.Address, error) {
 	return key, *(*ethtypes.EthAddress)(ethAddr), addr, nil
 }
 
+// createEthereumAccount returns a random account, or the account at index when a mnemonic is given
+func createEthereumAccount(mnemonic, derivationPath string, index int) (*key.Key, ethtypes.EthAddress, address.Address, error) {
+	if mnemonic == "" {
+		return NewAccount()
+	}
+	return NewAccountFromMnemonic(mnemonic, derivationPath, uint32(index))
+}
+
 func appendEthereumKeyToJSONFile(path string, name string, key *key.Key, ethAddr ethtypes.EthAddress, filAddr address.Address) error {
 	if key == nil {
 		return fmt.Errorf("key is nil")
@@ -101,7 +128,7 @@ func appendEthereumKeyToJSONFile(path string, name string, key *key.Key, ethAddr
 	// Add new account
 	accountsFile.Accounts[name] = AccountInfo{
 		Address:    filAddr.String(),
-		EthAddress: ethAddr.String(),
+		EthAddress: config.ChecksumAddress(ethAddr.String()),
 		PrivateKey: fmt.Sprintf("0x%x", key.PrivateKey),
 	}
 
@@ -164,16 +191,120 @@ func FundWallet(ctx context.Context, to address.Address, amount abi.TokenAmount,
 	}
 
 	if waitForConfirm {
-		// Wait for message to be included in a block
-		_, err = clientt.GetAPI().StateWaitMsg(ctx, smsg.Cid(), 5, abi.ChainEpoch(-1), true)
-		if err != nil {
-			return smsg, fmt.Errorf("failed to wait for message confirmation: %w", err)
+		if _, err := waitForMessage(ctx, clientt.GetAPI(), smsg.Cid()); err != nil {
+			return smsg, err
 		}
 	}
 
 	return smsg, nil
 }
 
+// msgWaiter is the part of the Lotus API used to wait for a message to land on chain
+type msgWaiter interface {
+	StateWaitMsg(ctx context.Context, msg cid.Cid, confidence uint64, limit abi.ChainEpoch, allowReplaced bool) (*api.MsgLookup, error)
+}
+
+// waitForMessage waits until msg is included with cfg.Confirmations epochs on top of it, and
+// fails if it did not execute successfully. Every message wait goes through here.
+func waitForMessage(ctx context.Context, node msgWaiter, msg cid.Cid) (*api.MsgLookup, error) {
+	lookup, err := node.StateWaitMsg(ctx, msg, cfg.Confirmations, abi.ChainEpoch(-1), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for message %s: %w", msg, err)
+	}
+	if lookup.Receipt.ExitCode.IsError() {
+		return lookup, fmt.Errorf("message %s failed with exit code %d", msg, lookup.Receipt.ExitCode)
+	}
+	return lookup, nil
+}
+
+// SweepAccount moves the balance of the delegated account from to the address to, signing with
+// from's secp256k1 private key. Enough is held back to pay for the transfer's gas, so a little
+// dust may remain. It returns the amount sent, which is zero when from holds nothing.
+func SweepAccount(ctx context.Context, privateKey []byte, from, to ethtypes.EthAddress) (abi.TokenAmount, ethtypes.EthHash, error) {
+	node := clientt.GetAPI()
+
+	fromAddr, err := from.ToFilecoinAddress()
+	if err != nil {
+		return big.Zero(), ethtypes.EthHash{}, fmt.Errorf("failed to convert %s to a Filecoin address: %w", from, err)
+	}
+
+	balance, err := GetBalance(ctx, fromAddr)
+	if err != nil {
+		return big.Zero(), ethtypes.EthHash{}, err
+	}
+	if balance.IsZero() {
+		return big.Zero(), ethtypes.EthHash{}, nil
+	}
+
+	gasParams, err := json.Marshal(ethtypes.EthEstimateGasParams{Tx: ethtypes.EthCall{
+		From: &from,
+		To:   &to,
+	}})
+	if err != nil {
+		return big.Zero(), ethtypes.EthHash{}, fmt.Errorf("failed to marshal gas params: %w", err)
+	}
+
+	gasLimit, err := node.EthEstimateGas(ctx, gasParams)
+	if err != nil {
+		return big.Zero(), ethtypes.EthHash{}, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+
+	gasPrice, err := node.EthGasPrice(ctx)
+	if err != nil {
+		return big.Zero(), ethtypes.EthHash{}, fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	priorityFee, err := node.EthMaxPriorityFeePerGas(ctx)
+	if err != nil {
+		return big.Zero(), ethtypes.EthHash{}, fmt.Errorf("failed to get max priority fee: %w", err)
+	}
+
+	// Headroom over the current price so a base fee rise does not strand the transfer
+	maxFee := big.Mul(big.Int(gasPrice), big.NewInt(2))
+	if maxFee.LessThan(big.Int(priorityFee)) {
+		maxFee = big.Int(priorityFee)
+	}
+
+	reserve := big.Mul(maxFee, big.NewInt(int64(gasLimit)))
+	amount := big.Sub(balance, reserve)
+	if amount.Sign() <= 0 {
+		return big.Zero(), ethtypes.EthHash{}, fmt.Errorf("balance %s does not cover the transfer gas (%s)", types.FIL(balance), types.FIL(reserve))
+	}
+
+	nonce, err := node.MpoolGetNonce(ctx, fromAddr)
+	if err != nil {
+		return big.Zero(), ethtypes.EthHash{}, fmt.Errorf("failed to get nonce: %w", err)
+	}
+
+	tx := ethtypes.Eth1559TxArgs{
+		ChainID:              config.DefaultChainID,
+		To:                   &to,
+		Value:                amount,
+		Nonce:                int(nonce),
+		MaxFeePerGas:         maxFee,
+		MaxPriorityFeePerGas: big.Int(priorityFee),
+		GasLimit:             int(gasLimit),
+		V:                    big.Zero(),
+		R:                    big.Zero(),
+		S:                    big.Zero(),
+	}
+
+	if err := SignTransaction(&tx, privateKey); err != nil {
+		return big.Zero(), ethtypes.EthHash{}, err
+	}
+
+	txHash, err := SubmitTransaction(ctx, node, &tx)
+	if err != nil {
+		return big.Zero(), ethtypes.EthHash{}, err
+	}
+
+	if _, err := waitForTransactionReceipt(ctx, os.Stdout, node, txHash); err != nil {
+		return big.Zero(), txHash, err
+	}
+
+	return amount, txHash, nil
+}
+
 // CreateEthKeystore creates an Ethereum keystore file from a private key
 // Returns the path to the created keystore file and the address
 func CreateEthKeystore(privateKey *ecdsa.PrivateKey, password string, outputDir string) (string, string, error) {
@@ -244,6 +375,117 @@ func GenerateNewEthKeystore(password string, outputDir string) (string, string,
 	return keystoreFile, address, privateKeyHex, nil
 }
 
+// WalletBalance is the result of a single balance lookup
+type WalletBalance struct {
+	Address address.Address
+	Balance abi.TokenAmount
+	Err     error
+}
+
+// GetBalances looks up the balances of the given addresses concurrently, preserving order
+func GetBalances(ctx context.Context, addrs []address.Address) []WalletBalance {
+	results := make([]WalletBalance, len(addrs))
+
+	var wg sync.WaitGroup
+	for i, addr := range addrs {
+		wg.Add(1)
+		go func(i int, addr address.Address) {
+			defer wg.Done()
+			balance, err := GetBalance(ctx, addr)
+			results[i] = WalletBalance{Address: addr, Balance: balance, Err: err}
+		}(i, addr)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// walletJSON is the JSON representation of a wallet in `wallet list --json`
+type walletJSON struct {
+	Address string `json:"address"`
+	Balance string `json:"balance,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+func printWalletsJSON(ctx context.Context, wallets []address.Address) error {
+	out := make([]walletJSON, 0, len(wallets))
+	for _, result := range GetBalances(ctx, wallets) {
+		entry := walletJSON{Address: result.Address.String()}
+		if result.Err != nil {
+			entry.Error = result.Err.Error()
+		} else {
+			entry.Balance = result.Balance.String()
+		}
+		out = append(out, entry)
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal wallets: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+func showAllBalances(ctx context.Context, workspace string) error {
+	wallets, err := ListWallets(ctx)
+	if err != nil {
+		return err
+	}
+
+	labels := make([]string, 0, len(wallets))
+	for _, addr := range wallets {
+		labels = append(labels, addr.String())
+	}
+
+	if workspace != "" {
+		accounts, err := loadAccounts(workspace)
+		if err != nil {
+			return fmt.Errorf("failed to load accounts: %w", err)
+		}
+
+		roles := make([]string, 0, len(accounts.Accounts))
+		for role := range accounts.Accounts {
+			roles = append(roles, role)
+		}
+		sort.Strings(roles)
+
+		for _, role := range roles {
+			addr, err := address.NewFromString(accounts.Accounts[role].Address)
+			if err != nil {
+				return fmt.Errorf("invalid address for account '%s': %w", role, err)
+			}
+			wallets = append(wallets, addr)
+			labels = append(labels, fmt.Sprintf("%s (%s)", addr, role))
+		}
+	}
+
+	if len(wallets) == 0 {
+		fmt.Println("No wallets found")
+		return nil
+	}
+
+	total := big.Zero()
+	failed := 0
+	for i, result := range GetBalances(ctx, wallets) {
+		if result.Err != nil {
+			fmt.Printf("%d. %s (balance: error - %v)\n", i+1, labels[i], result.Err)
+			failed++
+			continue
+		}
+		total = types.BigAdd(total, result.Balance)
+		filBalance := types.BigDiv(result.Balance, types.NewInt(1e18))
+		fmt.Printf("%d. %s (balance: %s FIL)\n", i+1, labels[i], filBalance.String())
+	}
+
+	filTotal := types.BigDiv(total, types.NewInt(1e18))
+	fmt.Printf("\nTotal across %d wallet(s): %s FIL (%s attoFIL)\n", len(wallets)-failed, filTotal.String(), total.String())
+	if failed > 0 {
+		fmt.Printf("Warning: %d wallet(s) could not be queried and are excluded from the total\n", failed)
+	}
+	return nil
+}
+
 var WalletCmd = &cli.Command{
 	Name:  "wallet",
 	Usage: "Wallet operations",
@@ -283,9 +525,27 @@ var WalletCmd = &cli.Command{
 					Name:  "name",
 					Usage: "Account name for generated wallet (required with --key-output)",
 				},
+				&cli.StringFlag{
+					Name:    "mnemonic",
+					Usage:   "BIP-39 mnemonic to derive deterministic wallets from (Ethereum wallets) (env: FILWIZARD_MNEMONIC)",
+					EnvVars: []string{"FILWIZARD_MNEMONIC"},
+				},
+				&cli.BoolFlag{
+					Name:  "generate-mnemonic",
+					Usage: "Generate and print a new BIP-39 mnemonic, then derive wallets from it (Ethereum wallets)",
+				},
+				&cli.StringFlag{
+					Name:  "derivation-path",
+					Value: DefaultDerivationPath,
+					Usage: "HD derivation path for --mnemonic and --seed; the wallet index is appended",
+				},
+				&cli.StringFlag{
+					Name:  "seed",
+					Usage: "Hex seed to derive deterministic secp256k1 wallets from, imported into the node (Filecoin wallets)",
+				},
 			},
 			Action: func(c *cli.Context) error {
-				ctx := context.Background()
+				ctx := c.Context
 
 				count := c.Int("count")
 				walletType := c.String("type")
@@ -312,10 +572,31 @@ var WalletCmd = &cli.Command{
 						return fmt.Errorf("--name is required when using --key-output")
 					}
 
+					mnemonic := c.String("mnemonic")
+					derivationPath := c.String("derivation-path")
+					if c.Bool("generate-mnemonic") {
+						if mnemonic != "" {
+							return fmt.Errorf("--mnemonic and --generate-mnemonic are mutually exclusive")
+						}
+						var err error
+						mnemonic, err = GenerateMnemonic()
+						if err != nil {
+							return err
+						}
+						fmt.Printf("Mnemonic (store it safely to recreate these wallets):\n  %s\n\n", mnemonic)
+					}
+
+					if c.IsSet("seed") {
+						return fmt.Errorf("--seed applies to Filecoin wallets; use --mnemonic for Ethereum wallets")
+					}
+
 					fmt.Printf("Creating %d Ethereum wallet(s):\n", count)
+					if mnemonic != "" {
+						fmt.Printf("Deriving from mnemonic at %s/<index>\n", derivationPath)
+					}
 
 					for i := 0; i < count; i++ {
-						key, ethAddr, filAddr, err := NewAccount()
+						key, ethAddr, filAddr, err := createEthereumAccount(mnemonic, derivationPath, i)
 						if err != nil {
 							return fmt.Errorf("failed to create wallet %d: %w", i+1, err)
 						}
@@ -365,10 +646,33 @@ var WalletCmd = &cli.Command{
 						return fmt.Errorf("invalid key type: %s (use secp256k1 or bls)", keyTypeStr)
 					}
 
+					var seed []byte
+					if seedHex := c.String("seed"); seedHex != "" {
+						if keyType != types.KTSecp256k1 {
+							return fmt.Errorf("--seed only supports secp256k1 wallets")
+						}
+						var err error
+						seed, err = parseSeed(seedHex)
+						if err != nil {
+							return err
+						}
+						fmt.Printf("Deriving from seed at %s/<index>\n", c.String("derivation-path"))
+					}
+
 					// Create wallets
 					createdWallets := make([]address.Address, 0, count)
 					for i := 0; i < count; i++ {
-						addr, err := CreateWallet(ctx, keyType)
+						var addr address.Address
+						var err error
+						if seed != nil {
+							var k *key.Key
+							k, err = NewKeyFromSeed(seed, c.String("derivation-path"), uint32(i))
+							if err == nil {
+								addr, err = ImportWallet(ctx, k)
+							}
+						} else {
+							addr, err = CreateWallet(ctx, keyType)
+						}
 						if err != nil {
 							return fmt.Errorf("failed to create wallet %d: %w", i+1, err)
 						}
@@ -394,14 +698,24 @@ var WalletCmd = &cli.Command{
 		{
 			Name:  "list",
 			Usage: "List wallets",
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "json",
+					Usage: "Output wallets and balances as JSON",
+				},
+			},
 			Action: func(c *cli.Context) error {
-				ctx := context.Background()
+				ctx := c.Context
 
 				wallets, err := ListWallets(ctx)
 				if err != nil {
 					return err
 				}
 
+				if jsonOutput(c) {
+					return printWalletsJSON(ctx, wallets)
+				}
+
 				if len(wallets) == 0 {
 					fmt.Println("No wallets found")
 					return nil
@@ -430,7 +744,7 @@ var WalletCmd = &cli.Command{
 					return fmt.Errorf("expected 2 arguments: <address> <amount>")
 				}
 
-				ctx := context.Background()
+				ctx := c.Context
 
 				addr, err := address.NewFromString(c.Args().Get(0))
 				if err != nil {
@@ -459,13 +773,27 @@ var WalletCmd = &cli.Command{
 			Name:      "balance",
 			Usage:     "Get wallet balance",
 			ArgsUsage: "<address>",
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "all",
+					Usage: "Show balances and the total across all node wallets",
+				},
+				&cli.StringFlag{
+					Name:  "workspace",
+					Usage: "Also include accounts from this workspace's accounts.json (with --all)",
+				},
+			},
 			Action: func(c *cli.Context) error {
+				ctx := c.Context
+
+				if c.Bool("all") {
+					return showAllBalances(ctx, c.String("workspace"))
+				}
+
 				if c.NArg() != 1 {
 					return fmt.Errorf("expected 1 argument: <address>")
 				}
 
-				ctx := context.Background()
-
 				addr, err := address.NewFromString(c.Args().Get(0))
 				if err != nil {
 					return fmt.Errorf("invalid address: %w", err)
@@ -580,5 +908,67 @@ var WalletCmd = &cli.Command{
 				return nil
 			},
 		},
+		{
+			Name:  "sign-typed",
+			Usage: "Sign an EIP-712 typed-data document with a workspace account",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "from",
+					Usage:    "Account role (or its address) to sign with",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "typed-data",
+					Usage:    "Typed-data JSON, as for eth_signTypedData_v4, or a path to a file containing it",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  "workspace",
+					Usage: "Workspace directory",
+					Value: "./workspace",
+				},
+			},
+			Action: signTypedData,
+		},
 	},
 }
+
+// signTypedData prints the EIP-712 signature of a workspace account over a typed-data document
+func signTypedData(c *cli.Context) error {
+	accounts, err := loadAccounts(c.String("workspace"))
+	if err != nil {
+		return fmt.Errorf("failed to load accounts: %w", err)
+	}
+	role, account, ok := findAccount(c.Context, accounts, c.String("from"))
+	if !ok {
+		return fmt.Errorf("account '%s' not found", c.String("from"))
+	}
+	privateKey, err := parsePrivateKey(account.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("invalid private key for '%s': %w", role, err)
+	}
+
+	raw := []byte(c.String("typed-data"))
+	if trimmed := strings.TrimSpace(string(raw)); !strings.HasPrefix(trimmed, "{") {
+		raw, err = os.ReadFile(trimmed)
+		if err != nil {
+			return fmt.Errorf("failed to read typed data: %w", err)
+		}
+	}
+
+	typedData, err := config.ParseTypedData(raw)
+	if err != nil {
+		return err
+	}
+	sig, err := config.SignTypedData(privateKey, typedData)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Signer:    %s (%s)\n", role, crypto.PubkeyToAddress(privateKey.PublicKey).Hex())
+	fmt.Printf("Signature: 0x%x\n", sig)
+	fmt.Printf("  r: 0x%x\n", sig[:32])
+	fmt.Printf("  s: 0x%x\n", sig[32:64])
+	fmt.Printf("  v: %d\n", sig[64])
+	return nil
+}
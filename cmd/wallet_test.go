@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/ipfs/go-cid"
+	"github.com/parthshah1/mpool-tx/config"
+)
+
+// fakeMsgWaiter records the arguments of its StateWaitMsg call
+type fakeMsgWaiter struct {
+	exitCode      exitcode.ExitCode
+	confidence    uint64
+	limit         abi.ChainEpoch
+	allowReplaced bool
+}
+
+func (w *fakeMsgWaiter) StateWaitMsg(ctx context.Context, msg cid.Cid, confidence uint64, limit abi.ChainEpoch, allowReplaced bool) (*api.MsgLookup, error) {
+	w.confidence, w.limit, w.allowReplaced = confidence, limit, allowReplaced
+	return &api.MsgLookup{Message: msg, Receipt: types.MessageReceipt{ExitCode: w.exitCode}, Height: 10}, nil
+}
+
+func TestWaitForMessage(t *testing.T) {
+	saved := cfg
+	t.Cleanup(func() { cfg = saved })
+
+	tests := []struct {
+		name          string
+		confirmations uint64
+		exitCode      exitcode.ExitCode
+		wantErr       string
+	}{
+		{name: "default confirmations", confirmations: config.DefaultConfirmations},
+		{name: "configured confirmations", confirmations: 12},
+		{name: "no confirmations", confirmations: 0},
+		{name: "failed message", confirmations: 3, exitCode: exitcode.ErrInsufficientFunds, wantErr: "failed with exit code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg = &config.Config{Confirmations: tt.confirmations}
+			waiter := &fakeMsgWaiter{exitCode: tt.exitCode}
+
+			_, err := waitForMessage(context.Background(), waiter, cid.Undef)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("waitForMessage: %v", err)
+			}
+
+			if waiter.confidence != tt.confirmations {
+				t.Errorf("StateWaitMsg confidence = %d, want %d", waiter.confidence, tt.confirmations)
+			}
+			if waiter.limit != -1 || !waiter.allowReplaced {
+				t.Errorf("StateWaitMsg limit = %d, allowReplaced = %v, want -1 and true", waiter.limit, waiter.allowReplaced)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/parthshah1/mpool-tx/config"
+	"github.com/urfave/cli/v2"
+)
+
+// workspaceSettingsFile holds the connection settings bound to a workspace
+const workspaceSettingsFile = "workspace.json"
+
+// WorkspaceSettings is the node a workspace is bound to, which contract methods may be called
+// on it, and how to display enum results
+type WorkspaceSettings struct {
+	RPC     string `json:"rpc,omitempty"`
+	ChainID int64  `json:"chain_id,omitempty"`
+
+	// AllowedMethods, when set, are the only methods `contract call write` may send
+	AllowedMethods []string `json:"allowed_methods,omitempty"`
+	// DeniedMethods may never be sent, even if allowed
+	DeniedMethods []string `json:"denied_methods,omitempty"`
+
+	// Enums names the members of Solidity enums, by enum type, for labelling read results
+	Enums map[string][]string `json:"enums,omitempty"`
+}
+
+// loadWorkspaceSettings reads the workspace's settings, returning nil if none are saved
+func loadWorkspaceSettings(workspace string) (*WorkspaceSettings, error) {
+	data, err := os.ReadFile(filepath.Join(workspace, workspaceSettingsFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workspace settings: %w", err)
+	}
+
+	var settings WorkspaceSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", workspaceSettingsFile, err)
+	}
+	return &settings, nil
+}
+
+// checkMethodPolicy rejects a state-changing call to method when the workspace denies it, or
+// when the workspace has an allowlist that does not include it
+func checkMethodPolicy(workspace, method string) error {
+	settings, err := loadWorkspaceSettings(workspace)
+	if err != nil || settings == nil {
+		return err
+	}
+
+	path := filepath.Join(workspace, workspaceSettingsFile)
+	for _, denied := range settings.DeniedMethods {
+		if denied == method {
+			return fmt.Errorf("method %s is blocked by denied_methods in %s", method, path)
+		}
+	}
+	if len(settings.AllowedMethods) == 0 {
+		return nil
+	}
+	for _, allowed := range settings.AllowedMethods {
+		if allowed == method {
+			return nil
+		}
+	}
+	return fmt.Errorf("method %s is not in allowed_methods in %s", method, path)
+}
+
+// checkSelectorPolicy applies the method policy to raw calldata sent to targetAddr. The selector
+// is looked up in the target's recorded ABI; calldata whose selector cannot be named that way
+// is rejected when the workspace has an allowlist, since it cannot be shown to be allowed.
+func checkSelectorPolicy(workspace string, deployments []DeploymentRecord, targetAddr string, callData []byte) error {
+	settings, err := loadWorkspaceSettings(workspace)
+	if err != nil || settings == nil || len(callData) < 4 {
+		return err
+	}
+	if len(settings.AllowedMethods) == 0 && len(settings.DeniedMethods) == 0 {
+		return nil
+	}
+
+	for _, record := range deployments {
+		if !strings.EqualFold(record.Address, targetAddr) || record.ABIPath == "" {
+			continue
+		}
+		parsedABI, err := loadABI(record.ABIPath)
+		if err != nil {
+			break
+		}
+		if method, err := parsedABI.MethodById(callData[:4]); err == nil {
+			return checkMethodPolicy(workspace, method.Name)
+		}
+		break
+	}
+
+	if len(settings.AllowedMethods) > 0 {
+		return fmt.Errorf("selector 0x%x is not a known method of %s, and %s has allowed_methods set", callData[:4], targetAddr, filepath.Join(workspace, workspaceSettingsFile))
+	}
+	return nil
+}
+
+func saveWorkspaceSettings(workspace string, settings *WorkspaceSettings) error {
+	if err := os.MkdirAll(workspace, 0755); err != nil {
+		return fmt.Errorf("failed to create workspace: %w", err)
+	}
+
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal workspace settings: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(workspace, workspaceSettingsFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to save workspace settings: %w", err)
+	}
+	return nil
+}
+
+var workspaceCmd = &cli.Command{
+	Name:  "workspace",
+	Usage: "Bind a workspace to an RPC endpoint and chain ID",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "set",
+			Usage: "Save the RPC (and chain ID) used by commands operating on this workspace",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "rpc-url",
+					Usage:    "RPC URL to bind the workspace to",
+					Required: true,
+				},
+				&cli.Int64Flag{
+					Name:  "chain-id",
+					Usage: "Expected chain ID (default: detected from the RPC)",
+				},
+				&cli.StringFlag{
+					Name:  "workspace",
+					Usage: "Workspace directory",
+					Value: "./workspace",
+				},
+			},
+			Action: setWorkspaceSettings,
+		},
+		{
+			Name:  "show",
+			Usage: "Show the connection settings in effect for this workspace",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "workspace",
+					Usage: "Workspace directory",
+					Value: "./workspace",
+				},
+			},
+			Action: showWorkspaceSettings,
+		},
+	},
+}
+
+func setWorkspaceSettings(c *cli.Context) error {
+	workspace := c.String("workspace")
+	rpcURL := c.String("rpc-url")
+
+	chainID := c.Int64("chain-id")
+	if chainID == 0 {
+		client, err := config.DialEthClient(rpcURL, cfg.Token)
+		if err != nil {
+			return fmt.Errorf("failed to connect to %s: %w", rpcURL, err)
+		}
+		detected, err := client.ChainID(c.Context)
+		client.Close()
+		if err != nil {
+			return fmt.Errorf("failed to detect chain ID (pass --chain-id): %w", err)
+		}
+		chainID = detected.Int64()
+	} else if err := verifyChainID(c.Context, rpcURL, chainID); err != nil {
+		return err
+	}
+
+	// Keep any method policy already saved in the workspace
+	settings, err := loadWorkspaceSettings(workspace)
+	if err != nil {
+		return err
+	}
+	if settings == nil {
+		settings = &WorkspaceSettings{}
+	}
+	settings.RPC = rpcURL
+	settings.ChainID = chainID
+	if err := saveWorkspaceSettings(workspace, settings); err != nil {
+		return err
+	}
+
+	fmt.Printf("Workspace %s bound to %s (chain ID %d)\n", workspace, rpcURL, chainID)
+	return nil
+}
+
+func showWorkspaceSettings(c *cli.Context) error {
+	workspace := c.String("workspace")
+
+	settings, err := loadWorkspaceSettings(workspace)
+	if err != nil {
+		return err
+	}
+	wcfg, err := resolveWorkspaceConfig(workspace)
+	if err != nil {
+		return err
+	}
+
+	if settings == nil {
+		fmt.Printf("No settings saved in %s\n", filepath.Join(workspace, workspaceSettingsFile))
+	} else {
+		fmt.Printf("Saved RPC: %s\n", settings.RPC)
+		fmt.Printf("Saved chain ID: %d\n", settings.ChainID)
+		if len(settings.AllowedMethods) > 0 {
+			fmt.Printf("Allowed methods: %s\n", strings.Join(settings.AllowedMethods, ", "))
+		}
+		if len(settings.DeniedMethods) > 0 {
+			fmt.Printf("Denied methods: %s\n", strings.Join(settings.DeniedMethods, ", "))
+		}
+	}
+	fmt.Printf("Effective RPC: %s", wcfg.RPC)
+	if rpcOverridden {
+		fmt.Print(" (from --rpc/FILECOIN_RPC)")
+	}
+	fmt.Println()
+	fmt.Printf("Effective chain ID: %d\n", wcfg.ChainID)
+	return nil
+}
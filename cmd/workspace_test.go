@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/parthshah1/mpool-tx/config"
+)
+
+// testWorkspace returns a workspace directory with settings saved, or none when settings is nil
+func testWorkspace(t *testing.T, settings *WorkspaceSettings) string {
+	t.Helper()
+
+	workspace := t.TempDir()
+	if settings != nil {
+		if err := saveWorkspaceSettings(workspace, settings); err != nil {
+			t.Fatalf("saveWorkspaceSettings: %v", err)
+		}
+	}
+	return workspace
+}
+
+func TestCheckMethodPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings *WorkspaceSettings
+		method   string
+		wantErr  string
+	}{
+		{name: "no settings", method: "upgradeTo"},
+		{name: "no policy", settings: &WorkspaceSettings{RPC: "http://localhost:1234/rpc/v1"}, method: "upgradeTo"},
+		{name: "denied", settings: &WorkspaceSettings{DeniedMethods: []string{"upgradeTo"}}, method: "upgradeTo", wantErr: "blocked by denied_methods"},
+		{name: "not denied", settings: &WorkspaceSettings{DeniedMethods: []string{"upgradeTo"}}, method: "transfer"},
+		{name: "names match exactly", settings: &WorkspaceSettings{DeniedMethods: []string{"upgradeTo"}}, method: "UpgradeTo"},
+		{name: "allowed", settings: &WorkspaceSettings{AllowedMethods: []string{"transfer"}}, method: "transfer"},
+		{name: "not allowed", settings: &WorkspaceSettings{AllowedMethods: []string{"transfer"}}, method: "approve", wantErr: "not in allowed_methods"},
+		{
+			name:     "denied wins over allowed",
+			settings: &WorkspaceSettings{AllowedMethods: []string{"upgradeTo"}, DeniedMethods: []string{"upgradeTo"}},
+			method:   "upgradeTo",
+			wantErr:  "blocked by denied_methods",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkMethodPolicy(testWorkspace(t, tt.settings), tt.method)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkMethodPolicy: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckSelectorPolicy(t *testing.T) {
+	deployments := writeTestDeployments(t)
+	selector := func(signature string) []byte {
+		// Calldata is the selector followed by one zero argument word
+		return append(crypto.Keccak256([]byte(signature))[:4], make([]byte, 32)...)
+	}
+	upgradeTo := selector("upgradeTo(address)")
+	transfer := selector("transfer(address,uint256)")
+	unknown := selector("mint(address)")
+
+	tests := []struct {
+		name     string
+		settings *WorkspaceSettings
+		target   string
+		callData []byte
+		wantErr  string
+	}{
+		{name: "no policy", target: testTokenAddress, callData: upgradeTo},
+		{name: "denied method by selector", settings: &WorkspaceSettings{DeniedMethods: []string{"upgradeTo"}}, target: testTokenAddress, callData: upgradeTo, wantErr: "blocked by denied_methods"},
+		{name: "target address is case-insensitive", settings: &WorkspaceSettings{DeniedMethods: []string{"upgradeTo"}}, target: strings.ToUpper(testTokenAddress), callData: upgradeTo, wantErr: "blocked by denied_methods"},
+		{name: "other method with a denylist", settings: &WorkspaceSettings{DeniedMethods: []string{"upgradeTo"}}, target: testTokenAddress, callData: transfer},
+		{name: "allowed method by selector", settings: &WorkspaceSettings{AllowedMethods: []string{"transfer"}}, target: testTokenAddress, callData: transfer},
+		{name: "unknown selector with an allowlist", settings: &WorkspaceSettings{AllowedMethods: []string{"transfer"}}, target: testTokenAddress, callData: unknown, wantErr: "not a known method"},
+		{name: "unrecorded target with an allowlist", settings: &WorkspaceSettings{AllowedMethods: []string{"transfer"}}, target: "0x00000000000000000000000000000000000000bb", callData: transfer, wantErr: "not a known method"},
+		{name: "unrecorded target with a denylist", settings: &WorkspaceSettings{DeniedMethods: []string{"upgradeTo"}}, target: "0x00000000000000000000000000000000000000bb", callData: upgradeTo},
+		{name: "plain value transfer", settings: &WorkspaceSettings{AllowedMethods: []string{"transfer"}}, target: testTokenAddress, callData: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSelectorPolicy(testWorkspace(t, tt.settings), deployments, tt.target, tt.callData)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkSelectorPolicy: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadWorkspaceConfigChainID(t *testing.T) {
+	saved := cfg
+	t.Cleanup(func() { cfg = saved })
+	cfg = &config.Config{RPC: "http://127.0.0.1:1/rpc/v1"}
+
+	_, url := newFakeEthNode(t)
+
+	tests := []struct {
+		name     string
+		settings *WorkspaceSettings
+		wantRPC  string
+		wantErr  string
+	}{
+		{name: "no settings", wantRPC: "http://127.0.0.1:1/rpc/v1"},
+		{name: "saved chain ID matches", settings: &WorkspaceSettings{RPC: url, ChainID: testChainID}, wantRPC: url},
+		{name: "saved chain ID differs", settings: &WorkspaceSettings{RPC: url, ChainID: 314}, wantErr: "chain ID mismatch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wcfg, err := loadWorkspaceConfig(context.Background(), testWorkspace(t, tt.settings))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadWorkspaceConfig: %v", err)
+			}
+			if wcfg.RPC != tt.wantRPC {
+				t.Errorf("RPC = %s, want %s", wcfg.RPC, tt.wantRPC)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ParseAddress parses a 0x-prefixed hex address. Mixed-case input must carry a valid
+// EIP-55 checksum; all-lowercase or all-uppercase input is accepted as unchecksummed.
+func ParseAddress(value string) (common.Address, error) {
+	if !common.IsHexAddress(value) {
+		return common.Address{}, fmt.Errorf("invalid address %q: expected 0x followed by 40 hex characters", value)
+	}
+	if err := ValidateChecksum(value); err != nil {
+		return common.Address{}, err
+	}
+	return common.HexToAddress(value), nil
+}
+
+// ValidateChecksum verifies the EIP-55 checksum of a mixed-case hex address
+func ValidateChecksum(value string) error {
+	hexPart := strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+	if strings.ToLower(hexPart) == hexPart || strings.ToUpper(hexPart) == hexPart {
+		return nil
+	}
+
+	expected := common.HexToAddress(value).Hex()
+	if hexPart != expected[2:] {
+		return fmt.Errorf("invalid EIP-55 checksum for address %s (expected %s)", value, expected)
+	}
+	return nil
+}
+
+// ChecksumAddress returns the EIP-55 checksummed form of a hex address, or value unchanged if it is not one
+func ChecksumAddress(value string) string {
+	if !common.IsHexAddress(value) {
+		return value
+	}
+	return common.HexToAddress(value).Hex()
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAddress(t *testing.T) {
+	const checksummed = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr string
+	}{
+		{name: "valid checksum", value: checksummed, want: checksummed},
+		{name: "lowercase is normalized", value: strings.ToLower(checksummed), want: checksummed},
+		{name: "uppercase is normalized", value: "0x" + strings.ToUpper(checksummed[2:]), want: checksummed},
+		{name: "wrong checksum", value: "0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", wantErr: "invalid EIP-55 checksum"},
+		{name: "too short", value: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA", wantErr: "expected 0x followed by 40 hex characters"},
+		{name: "not hex", value: "Token", wantErr: "expected 0x followed by 40 hex characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := ParseAddress(tt.value)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAddress: %v", err)
+			}
+			if got := addr.Hex(); got != tt.want {
+				t.Errorf("address = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChecksumAddress(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+		{"0xfb6916095ca1df60bb79ce92ce3ea74c37c5d359", "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359"},
+		{"not-an-address", "not-an-address"},
+	}
+
+	for _, tt := range tests {
+		if got := ChecksumAddress(tt.value); got != tt.want {
+			t.Errorf("ChecksumAddress(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
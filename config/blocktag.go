@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ParseBlockTag converts a --block value into the block argument for eth_call: nil for
+// latest, the negative rpc.BlockNumber constants for pending/finalized/safe/earliest, or a block number
+// given in decimal or 0x hex
+func ParseBlockTag(tag string) (*big.Int, error) {
+	switch strings.ToLower(strings.TrimSpace(tag)) {
+	case "", "latest":
+		return nil, nil
+	case "pending":
+		return big.NewInt(int64(rpc.PendingBlockNumber)), nil
+	case "finalized":
+		return big.NewInt(int64(rpc.FinalizedBlockNumber)), nil
+	case "safe":
+		return big.NewInt(int64(rpc.SafeBlockNumber)), nil
+	case "earliest":
+		return big.NewInt(int64(rpc.EarliestBlockNumber)), nil
+	}
+
+	n, ok := new(big.Int).SetString(strings.TrimSpace(tag), 0)
+	if !ok || n.Sign() < 0 {
+		return nil, fmt.Errorf("invalid block %q: expected latest, pending, finalized, safe, earliest, or a block number", tag)
+	}
+	return n, nil
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// blockRecorder answers eth_call with an empty result and records the block it was asked for
+type blockRecorder struct {
+	mu    sync.Mutex
+	block string
+}
+
+func (r *blockRecorder) Call(args fakeCallArgs, block string) hexutil.Bytes {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.block = block
+	return hexutil.Bytes{}
+}
+
+func TestParseBlockTag(t *testing.T) {
+	recorder := &blockRecorder{}
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", recorder); err != nil {
+		t.Fatalf("failed to register eth service: %v", err)
+	}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	defer server.Stop()
+
+	wrapper, err := NewContractWrapper(httpServer.URL, "", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	if err != nil {
+		t.Fatalf("NewContractWrapper: %v", err)
+	}
+
+	tests := []struct {
+		tag     string
+		want    string // block argument sent with eth_call
+		wantErr string
+	}{
+		{tag: "", want: "latest"},
+		{tag: "latest", want: "latest"},
+		{tag: "Pending", want: "pending"},
+		{tag: "finalized", want: "finalized"},
+		{tag: "safe", want: "safe"},
+		{tag: "earliest", want: "earliest"},
+		{tag: "0", want: "0x0"},
+		{tag: "12345", want: "0x3039"},
+		{tag: "0x3039", want: "0x3039"},
+		{tag: "-1", wantErr: "invalid block"},
+		{tag: "tomorrow", wantErr: "invalid block"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			block, err := ParseBlockTag(tt.tag)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseBlockTag: %v", err)
+			}
+
+			wrapper.SetBlock(block)
+			if _, err := wrapper.CallMethod(context.Background(), "totalSupply", nil); err != nil {
+				t.Fatalf("CallMethod: %v", err)
+			}
+			recorder.mu.Lock()
+			defer recorder.mu.Unlock()
+			if recorder.block != tt.want {
+				t.Errorf("eth_call block = %q, want %q", recorder.block, tt.want)
+			}
+		})
+	}
+}
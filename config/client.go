@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/lotus/api"
 	"github.com/filecoin-project/lotus/api/client"
 )
@@ -30,7 +33,7 @@ func New(cfg *Config) (*Client, error) {
 	}
 
 	// Connect to Filecoin node with authentication
-	fullNodeAPI, closer, err := client.NewFullNodeRPCV1(context.Background(), cfg.RPC, headers)
+	fullNodeAPI, closer, err := client.NewFullNodeRPCV1(context.Background(), cfg.RPC, headers, jsonrpc.WithHTTPClient(rpcHTTPClient()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to Lotus node at %s: %w", cfg.RPC, err)
 	}
@@ -58,3 +61,18 @@ func (c *Client) GetConfig() *Config {
 func (c *Client) GetAPI() api.FullNode {
 	return c.api
 }
+
+// DialEthClient connects an Ethereum JSON-RPC client, sending the JWT token as a bearer header when set
+func DialEthClient(rpcURL, token string) (*ethclient.Client, error) {
+	opts := []rpc.ClientOption{rpc.WithHTTPClient(rpcHTTPClient())}
+	if token != "" {
+		opts = append(opts, rpc.WithHeader("Authorization", "Bearer "+token))
+	}
+
+	rpcClient, err := rpc.DialOptions(context.Background(), rpcURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return ethclient.NewClient(rpcClient), nil
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestDialEthClientAuthorization(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{name: "token configured", token: "secret", want: "Bearer secret"},
+		{name: "no token", token: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var got []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				got = append(got, r.Header.Get("Authorization"))
+				mu.Unlock()
+
+				var req struct {
+					ID json.RawMessage `json:"id"`
+				}
+				json.NewDecoder(r.Body).Decode(&req)
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": "0x1"})
+			}))
+			defer server.Close()
+
+			client, err := DialEthClient(server.URL, tt.token)
+			if err != nil {
+				t.Fatalf("DialEthClient: %v", err)
+			}
+			defer client.Close()
+
+			if _, err := client.BlockNumber(context.Background()); err != nil {
+				t.Fatalf("BlockNumber: %v", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if len(got) == 0 {
+				t.Fatal("server received no requests")
+			}
+			for _, header := range got {
+				if header != tt.want {
+					t.Errorf("Authorization = %q, want %q", header, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// rpcLimit bounds the number of RPC requests in flight across every client dialed by this
+// process, whether it was dialed before or after the limit was set
+var rpcLimit requestLimiter
+
+// requestLimiter holds the current concurrency slots; nil slots means no limit
+type requestLimiter struct {
+	mu    sync.Mutex
+	slots chan struct{}
+}
+
+// SetRPCConcurrency limits simultaneous outstanding RPC requests to n (0 = unlimited). It
+// applies to the Ethereum and Lotus clients alike, including ones already dialed.
+func SetRPCConcurrency(n int) {
+	rpcLimit.mu.Lock()
+	defer rpcLimit.mu.Unlock()
+
+	if n <= 0 {
+		rpcLimit.slots = nil
+		return
+	}
+	rpcLimit.slots = make(chan struct{}, n)
+}
+
+// current returns the slots requests acquire now, or nil when there is no limit
+func (l *requestLimiter) current() chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.slots
+}
+
+// rpcHTTPClient returns the HTTP client every RPC client is dialed with. It holds a
+// concurrency slot for each request until its response body is closed.
+func rpcHTTPClient() *http.Client {
+	return &http.Client{Transport: &limitedTransport{base: http.DefaultTransport, limiter: &rpcLimit}}
+}
+
+// limitedTransport is an http.RoundTripper that waits for a free slot before each request
+type limitedTransport struct {
+	base    http.RoundTripper
+	limiter *requestLimiter
+}
+
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	slots := t.limiter.current()
+	if slots == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	select {
+	case slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		<-slots
+		return nil, err
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: func() { <-slots }}
+	return resp, nil
+}
+
+// releasingBody frees its request's slot once the response has been read and closed
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
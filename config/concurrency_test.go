@@ -0,0 +1,127 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingRPCServer answers every JSON-RPC request only once released, and records how many
+// requests it held at once
+type blockingRPCServer struct {
+	release  chan struct{}
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+func (s *blockingRPCServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	s.inFlight++
+	s.peak = max(s.peak, s.inFlight)
+	s.mu.Unlock()
+
+	<-s.release
+
+	s.mu.Lock()
+	s.inFlight--
+	s.mu.Unlock()
+
+	var result interface{} = "0x1"
+	if req.Method == "Filecoin.Version" {
+		result = map[string]interface{}{"Version": "test", "APIVersion": 0, "BlockDelay": 30}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+}
+
+func (s *blockingRPCServer) held() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inFlight
+}
+
+func TestRPCConcurrencyLimit(t *testing.T) {
+	const limit, requests = 2, 5
+
+	server := &blockingRPCServer{release: make(chan struct{})}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	// Both clients are dialed before the limit is set, and are still bound by it
+	ethClient, err := DialEthClient(httpServer.URL, "")
+	if err != nil {
+		t.Fatalf("DialEthClient: %v", err)
+	}
+	defer ethClient.Close()
+	lotusClient, err := New(&Config{RPC: httpServer.URL})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer lotusClient.Close()
+
+	SetRPCConcurrency(limit)
+	t.Cleanup(func() { SetRPCConcurrency(0) })
+
+	calls := map[string]func(context.Context) error{
+		"eth": func(ctx context.Context) error {
+			_, err := ethClient.BlockNumber(ctx)
+			return err
+		},
+		"lotus": func(ctx context.Context) error {
+			_, err := lotusClient.GetAPI().Version(ctx)
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			errs := make(chan error, requests)
+			for i := 0; i < requests; i++ {
+				go func() { errs <- call(ctx) }()
+			}
+
+			deadline := time.Now().Add(5 * time.Second)
+			for server.held() < limit && time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+			}
+			// The remaining requests must wait for a slot rather than reach the server
+			time.Sleep(200 * time.Millisecond)
+			if held := server.held(); held != limit {
+				t.Errorf("server holds %d requests, want %d", held, limit)
+			}
+
+			for i := 0; i < requests; i++ {
+				server.release <- struct{}{}
+			}
+			for i := 0; i < requests; i++ {
+				if err := <-errs; err != nil {
+					t.Errorf("request failed: %v", err)
+				}
+			}
+
+			server.mu.Lock()
+			defer server.mu.Unlock()
+			if server.peak > limit {
+				t.Errorf("server saw %d concurrent requests, want at most %d", server.peak, limit)
+			}
+			server.peak = 0
+		})
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"time"
 )
 
+// DefaultChainID is the FEVM chain ID assumed when signing Ethereum transactions
+const DefaultChainID = 31415926
+
 // Config holds all configuration for filwizard
 type Config struct {
 	// Filecoin node connection
@@ -17,13 +20,35 @@ type Config struct {
 	DefaultKeyType string
 	MinBalance     int64 // attoFIL
 
+	// Confirmations is the number of epochs StateWaitMsg waits for after a message is included
+	Confirmations uint64
+
+	// RPCConcurrency caps simultaneous outstanding RPC requests (0 = unlimited)
+	RPCConcurrency int
+
+	// ReceiptTimeout is how long to wait for a sent transaction's receipt
+	ReceiptTimeout time.Duration
+
 	// Contract settings
 	ContractTimeout time.Duration
+	Multicall3      string // optional Multicall3 address for aggregated reads
+
+	// Output is the output format for commands that support structured output: text or json
+	Output string
 
 	// Logging
 	Verbose bool
 }
 
+// Output formats accepted by --output
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+// DefaultConfirmations is the confidence used when waiting for messages to land on chain
+const DefaultConfirmations = 5
+
 // Load creates a new config from environment variables
 func Load() *Config {
 	return &Config{
@@ -32,7 +57,12 @@ func Load() *Config {
 		Timeout:         getDuration("FILECOIN_TIMEOUT", 30*time.Second),
 		DefaultKeyType:  getEnv("DEFAULT_KEY_TYPE", "secp256k1"),
 		MinBalance:      getInt64("MIN_WALLET_BALANCE", 1000000000000000000), // 1 FIL
+		Confirmations:   getUint64("FILWIZARD_CONFIRMATIONS", DefaultConfirmations),
+		RPCConcurrency:  int(getInt64("FILWIZARD_RPC_CONCURRENCY", 0)),
+		ReceiptTimeout:  getDuration("FILWIZARD_RECEIPT_TIMEOUT", DefaultReceiptTimeout),
 		ContractTimeout: getDuration("CONTRACT_TIMEOUT", 5*time.Minute),
+		Multicall3:      getEnv("MULTICALL3_ADDRESS", ""),
+		Output:          getEnv("FILWIZARD_OUTPUT", OutputText),
 		Verbose:         getBool("VERBOSE", false),
 	}
 }
@@ -53,6 +83,17 @@ func getInt64(key string, fallback int64) int64 {
 	return fallback
 }
 
+// getUint64 is getInt64 for counts that cannot be negative; a negative value is rejected
+// rather than wrapping around to a huge count
+func getUint64(key string, fallback uint64) uint64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseUint(value, 10, 64); err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
+
 func getBool(key string, fallback bool) bool {
 	if value := os.Getenv(key); value != "" {
 		if parsed, err := strconv.ParseBool(value); err == nil {
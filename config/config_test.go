@@ -0,0 +1,26 @@
+package config
+
+import "testing"
+
+func TestLoadConfirmations(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  uint64
+	}{
+		{name: "unset", value: "", want: DefaultConfirmations},
+		{name: "configured", value: "12", want: 12},
+		{name: "zero", value: "0", want: 0},
+		{name: "negative is rejected", value: "-1", want: DefaultConfirmations},
+		{name: "not a number", value: "many", want: DefaultConfirmations},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("FILWIZARD_CONFIRMATIONS", tt.value)
+			if got := Load().Confirmations; got != tt.want {
+				t.Errorf("Confirmations = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ConstructorArg is one entry of a constructor args file: a Solidity type and its JSON value.
+// Scalars may be JSON strings, numbers, or booleans; arrays take a JSON array and tuples a JSON
+// array or object, as for typed call arguments.
+type ConstructorArg struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// LoadConstructorArgs reads a JSON array of {"type": ..., "value": ...} entries and checks that
+// every value converts to its type
+func LoadConstructorArgs(path string) ([]ConstructorArg, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read constructor args file: %w", err)
+	}
+
+	var args []ConstructorArg
+	if err := json.Unmarshal(data, &args); err != nil {
+		return nil, fmt.Errorf("failed to parse constructor args file: %w", err)
+	}
+
+	for i, arg := range args {
+		if _, err := arg.typed(); err != nil {
+			return nil, fmt.Errorf("constructor arg %d (%s): %w", i, arg.Type, err)
+		}
+	}
+	return args, nil
+}
+
+// typed converts the entry the same way a typed call argument (type:value) is converted
+func (a ConstructorArg) typed() (TypedArg, error) {
+	if !IsSolidityType(a.Type) {
+		return TypedArg{}, fmt.Errorf("unsupported type %q", a.Type)
+	}
+	return ConvertTypedArgument(a.text(), a.Type)
+}
+
+// text is the value in the string form ConvertTypedArgument takes: JSON strings unquoted,
+// anything else as its JSON text
+func (a ConstructorArg) text() string {
+	var s string
+	if err := json.Unmarshal(a.Value, &s); err == nil {
+		return s
+	}
+	return string(bytes.TrimSpace(a.Value))
+}
+
+// EncodeConstructorArgs ABI-encodes args, ready to append to a contract's creation bytecode
+func EncodeConstructorArgs(args []ConstructorArg) ([]byte, error) {
+	typed := make([]interface{}, len(args))
+	for i, arg := range args {
+		t, err := arg.typed()
+		if err != nil {
+			return nil, fmt.Errorf("constructor arg %d (%s): %w", i, arg.Type, err)
+		}
+		typed[i] = t
+	}
+	return encodeArguments(typed)
+}
+
+// ForgeConstructorArgs renders args as the literals `forge create --constructor-args` expects:
+// arrays as [a,b] and tuples as (a,b), with strings inside them quoted
+func ForgeConstructorArgs(args []ConstructorArg) ([]string, error) {
+	out := make([]string, len(args))
+	for i, arg := range args {
+		if _, err := arg.typed(); err != nil {
+			return nil, fmt.Errorf("constructor arg %d (%s): %w", i, arg.Type, err)
+		}
+
+		literal, err := forgeLiteral(arg)
+		if err != nil {
+			return nil, fmt.Errorf("constructor arg %d (%s): %w", i, arg.Type, err)
+		}
+		out[i] = literal
+	}
+	return out, nil
+}
+
+// forgeLiteral renders a single, already validated, constructor arg
+func forgeLiteral(arg ConstructorArg) (string, error) {
+	if !isTupleType(arg.Type) && !isArrayType(arg.Type) {
+		return arg.text(), nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(arg.Value))
+	decoder.UseNumber()
+	var raw interface{}
+	if err := decoder.Decode(&raw); err != nil {
+		return "", err
+	}
+
+	var elems []interface{}
+	var elemTypes []string
+	open, close := "[", "]"
+	if isTupleType(arg.Type) {
+		open, close = "(", ")"
+		components, err := parseTupleComponents(arg.Type)
+		if err != nil {
+			return "", err
+		}
+		for _, component := range components {
+			elemTypes = append(elemTypes, component.Type.String())
+		}
+		if obj, ok := raw.(map[string]interface{}); ok {
+			for _, component := range components {
+				elems = append(elems, obj[component.Name])
+			}
+		} else {
+			elems, _ = raw.([]interface{})
+		}
+	} else {
+		elems, _ = raw.([]interface{})
+		elemType := arg.Type[:strings.LastIndex(arg.Type, "[")]
+		for range elems {
+			elemTypes = append(elemTypes, elemType)
+		}
+	}
+
+	parts := make([]string, len(elems))
+	for i, elem := range elems {
+		s, err := jsonScalarString(elem)
+		if err != nil {
+			return "", err
+		}
+		if strings.EqualFold(elemTypes[i], "string") {
+			s = fmt.Sprintf("%q", s)
+		}
+		parts[i] = s
+	}
+	return open + strings.Join(parts, ",") + close, nil
+}
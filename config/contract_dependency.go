@@ -1,13 +1,17 @@
 package config
 
 import (
+	"context"
 	"crypto/ecdsa"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"math/big"
 	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -16,10 +20,12 @@ import (
 )
 
 type PostDeploymentAction struct {
-	Method      string   `json:"method"`
-	Args        []string `json:"args"`
-	Types       []string `json:"types"`
-	Description string   `json:"description,omitempty"`
+	Method          string   `json:"method"`
+	Args            []string `json:"args"`
+	Types           []string `json:"types"`
+	Description     string   `json:"description,omitempty"`
+	Target          string   `json:"target,omitempty"`
+	ContinueOnError bool     `json:"continue_on_error,omitempty"`
 }
 
 type PostDeployment struct {
@@ -43,11 +49,58 @@ type ContractConfig struct {
 	CloneCommands    []string          `json:"clone_commands,omitempty"`
 	Exports          map[string]string `json:"exports,omitempty"`
 	GenerateBindings bool              `json:"generate_bindings,omitempty"`
+	Deployer         string            `json:"deployer,omitempty"` // accounts.json role that deploys this contract instead of the global deployer
+}
+
+// NetworkConfig is a named deployment target selected with deploy-local --network
+type NetworkConfig struct {
+	RPC         string `json:"rpc"`
+	ChainID     int64  `json:"chain_id,omitempty"`
+	DeployerKey string `json:"deployer_key,omitempty"` // hex key, or $VAR to read it from the environment
 }
 
 type ContractsConfig struct {
-	Environment map[string]string `json:"environment,omitempty"`
-	Contracts   []ContractConfig  `json:"contracts"`
+	Environment map[string]string        `json:"environment,omitempty"`
+	Networks    map[string]NetworkConfig `json:"networks,omitempty"`
+	AddressBook map[string]string        `json:"address_book,omitempty"` // externally deployed contracts, by name; consulted after deployments
+	Contracts   []ContractConfig         `json:"contracts"`
+}
+
+// Network returns the named network, resolving a $VAR deployer key from the environment
+func (c *ContractsConfig) Network(name string) (*NetworkConfig, error) {
+	network, ok := c.Networks[name]
+	if !ok {
+		names := make([]string, 0, len(c.Networks))
+		for n := range c.Networks {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("network %q not defined in config (available: %s)", name, strings.Join(names, ", "))
+	}
+
+	if network.RPC == "" {
+		return nil, fmt.Errorf("network %q has no rpc", name)
+	}
+
+	if strings.HasPrefix(network.DeployerKey, "$") {
+		envVar := strings.TrimPrefix(network.DeployerKey, "$")
+		network.DeployerKey = os.Getenv(envVar)
+		if network.DeployerKey == "" {
+			return nil, fmt.Errorf("network %q deployer key: environment variable %s is not set", name, envVar)
+		}
+	}
+
+	return &network, nil
+}
+
+// NetworkFileName qualifies a workspace file name with the network, e.g.
+// deployments.json -> deployments.calibration.json; an empty network leaves it unchanged
+func NetworkFileName(base, network string) string {
+	if network == "" {
+		return base
+	}
+	ext := filepath.Ext(base)
+	return strings.TrimSuffix(base, ext) + "." + network + ext
 }
 
 type DeploymentRecord struct {
@@ -58,6 +111,8 @@ type DeploymentRecord struct {
 	TxHash             string `json:"txhash"`
 	ABIPath            string `json:"abi_path"`
 	BindingsPath       string `json:"bindings_path"`
+	Implementation     string `json:"implementation_address,omitempty"`
+	ConfigHash         string `json:"config_hash,omitempty"`
 }
 
 // LoadContractsConfig reads and parses the contracts configuration file
@@ -72,6 +127,13 @@ func LoadContractsConfig(configPath string) (*ContractsConfig, error) {
 		return nil, fmt.Errorf("failed to parse contracts config: %w", err)
 	}
 
+	for name, addr := range config.AddressBook {
+		if !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("address_book entry %s has invalid address %q", name, addr)
+		}
+		config.AddressBook[name] = common.HexToAddress(addr).Hex()
+	}
+
 	return &config, nil
 }
 
@@ -94,8 +156,53 @@ func LoadDeploymentRecords(deploymentsPath string) ([]DeploymentRecord, error) {
 	return deployments, nil
 }
 
+// Fingerprint returns a stable hash of the contract's configuration, used to detect config changes between runs
+func (c ContractConfig) Fingerprint() string {
+	data, _ := json.Marshal(c)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// FindLatestDeployment returns the most recent deployment record for name, or nil if it was never deployed
+func FindLatestDeployment(deployments []DeploymentRecord, name string) *DeploymentRecord {
+	for i := len(deployments) - 1; i >= 0; i-- {
+		if strings.EqualFold(deployments[i].Name, name) && deployments[i].Address != "" {
+			return &deployments[i]
+		}
+	}
+	return nil
+}
+
+// StampConfigHash records the config fingerprint on the latest deployment of name in deployments.json.
+// Records are rewritten as raw JSON so fields written by other tools are preserved.
+func StampConfigHash(deploymentsPath, name, hash string) error {
+	data, err := ioutil.ReadFile(deploymentsPath)
+	if err != nil {
+		return fmt.Errorf("failed to read deployments: %w", err)
+	}
+
+	var records []map[string]interface{}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return fmt.Errorf("failed to parse deployments: %w", err)
+	}
+
+	for i := len(records) - 1; i >= 0; i-- {
+		if recordName, _ := records[i]["name"].(string); strings.EqualFold(recordName, name) {
+			records[i]["config_hash"] = hash
+			break
+		}
+	}
+
+	data, err = json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal deployments: %w", err)
+	}
+
+	return ioutil.WriteFile(deploymentsPath, data, 0644)
+}
+
 // ResolveDependencies replaces template variables in constructor args with actual contract addresses
-func ResolveDependencies(contract ContractConfig, deployments []DeploymentRecord) ([]string, error) {
+func ResolveDependencies(contract ContractConfig, deployments []DeploymentRecord, addressBook map[string]string) ([]string, error) {
 	resolvedArgs := make([]string, len(contract.ConstructorArgs))
 
 	for i, arg := range contract.ConstructorArgs {
@@ -120,14 +227,14 @@ func ResolveDependencies(contract ContractConfig, deployments []DeploymentRecord
 		} else if strings.HasPrefix(arg, "${") && strings.HasSuffix(arg, "}") {
 			// Handle ${ContractName} format (legacy)
 			contractName := arg[2 : len(arg)-1]
-			address := findContractAddress(contractName, deployments)
+			address := findContractAddress(contractName, deployments, addressBook)
 			if address == "" {
 				return nil, fmt.Errorf("dependency contract %s not found in deployments", contractName)
 			}
 			resolved = address
 		} else if strings.Contains(arg, "{address:") {
 			// Handle {address:ContractName} format (new)
-			resolved = resolveAddressPlaceholders(arg, deployments)
+			resolved = resolveAddressPlaceholders(arg, deployments, addressBook)
 			if strings.Contains(resolved, "{address:") {
 				// Still contains unresolved placeholders
 				return nil, fmt.Errorf("unresolved address placeholder in argument: %s", arg)
@@ -160,7 +267,7 @@ func ResolveDependencies(contract ContractConfig, deployments []DeploymentRecord
 }
 
 // resolveAddressPlaceholders resolves {address:ContractName} placeholders in a string
-func resolveAddressPlaceholders(input string, deployments []DeploymentRecord) string {
+func resolveAddressPlaceholders(input string, deployments []DeploymentRecord, addressBook map[string]string) string {
 	result := input
 
 	// Handle multiple placeholders in a single string
@@ -179,7 +286,7 @@ func resolveAddressPlaceholders(input string, deployments []DeploymentRecord) st
 		placeholder := result[start : end+1]
 		contractName := placeholder[9 : len(placeholder)-1] // Extract from {address: to }
 
-		address := findContractAddress(contractName, deployments)
+		address := findContractAddress(contractName, deployments, addressBook)
 		if address == "" {
 			// Leave unresolved for error handling
 			break
@@ -276,9 +383,9 @@ func resolveDeploymentPlaceholders(input string, deployments []DeploymentRecord)
 }
 
 // ValidateDependencies checks if all required dependencies are deployed
-func ValidateDependencies(contract ContractConfig, deployments []DeploymentRecord) error {
+func ValidateDependencies(contract ContractConfig, deployments []DeploymentRecord, addressBook map[string]string) error {
 	for _, dep := range contract.Dependencies {
-		if findContractAddress(dep, deployments) == "" {
+		if findContractAddress(dep, deployments, addressBook) == "" {
 			return fmt.Errorf("required dependency %s is not deployed", dep)
 		}
 	}
@@ -321,12 +428,113 @@ func GetDeploymentOrder(contracts []ContractConfig) ([]ContractConfig, error) {
 	return ordered, nil
 }
 
-func findContractAddress(name string, deployments []DeploymentRecord) string {
+// FilterDeploymentOrder restricts an ordered contract list to the --only/--skip selection.
+// Dependencies of selected contracts that are not themselves selected must already be deployed.
+func FilterDeploymentOrder(ordered []ContractConfig, only, skip []string, deployments []DeploymentRecord, addressBook map[string]string) ([]ContractConfig, error) {
+	if len(only) == 0 && len(skip) == 0 {
+		return ordered, nil
+	}
+
+	known := make(map[string]bool)
+	for _, contract := range ordered {
+		known[strings.ToLower(contract.Name)] = true
+	}
+
+	onlySet := make(map[string]bool)
+	for _, name := range only {
+		if !known[strings.ToLower(name)] {
+			return nil, fmt.Errorf("contract %s not found in config", name)
+		}
+		onlySet[strings.ToLower(name)] = true
+	}
+
+	skipSet := make(map[string]bool)
+	for _, name := range skip {
+		if !known[strings.ToLower(name)] {
+			return nil, fmt.Errorf("contract %s not found in config", name)
+		}
+		skipSet[strings.ToLower(name)] = true
+	}
+
+	var filtered []ContractConfig
+	selected := make(map[string]bool)
+	for _, contract := range ordered {
+		key := strings.ToLower(contract.Name)
+		if len(onlySet) > 0 && !onlySet[key] {
+			continue
+		}
+		if skipSet[key] {
+			continue
+		}
+		filtered = append(filtered, contract)
+		selected[key] = true
+	}
+
+	for _, contract := range filtered {
+		for _, dep := range contract.Dependencies {
+			if selected[strings.ToLower(dep)] {
+				continue
+			}
+			if findContractAddress(dep, deployments, addressBook) == "" {
+				return nil, fmt.Errorf("%s depends on %s, which is not selected and not present in deployments.json", contract.Name, dep)
+			}
+		}
+	}
+
+	return filtered, nil
+}
+
+// ContinueFrom drops the contracts before name in an ordered list, to resume a failed run.
+// Every dependency of the remaining contracts that is not itself remaining must already be
+// recorded in deployments.
+func ContinueFrom(ordered []ContractConfig, name string, deployments []DeploymentRecord, addressBook map[string]string) ([]ContractConfig, error) {
+	start := -1
+	for i, contract := range ordered {
+		if strings.EqualFold(contract.Name, name) {
+			start = i
+			break
+		}
+	}
+	if start == -1 {
+		return nil, fmt.Errorf("contract %s is not in the deployment order", name)
+	}
+
+	remaining := ordered[start:]
+	pending := make(map[string]bool)
+	for _, contract := range remaining {
+		pending[strings.ToLower(contract.Name)] = true
+	}
+	for _, contract := range remaining {
+		for _, dep := range contract.Dependencies {
+			if pending[strings.ToLower(dep)] {
+				continue
+			}
+			if findContractAddress(dep, deployments, addressBook) == "" {
+				return nil, fmt.Errorf("%s depends on %s, which comes before %s but is not present in deployments.json", contract.Name, dep, name)
+			}
+		}
+	}
+
+	return remaining, nil
+}
+
+// findContractAddress looks name up in the deployment records, then in the address book
+func findContractAddress(name string, deployments []DeploymentRecord, addressBook map[string]string) string {
 	for _, deployment := range deployments {
 		if strings.EqualFold(deployment.Name, name) {
 			return deployment.Address
 		}
 	}
+	return lookupAddressBook(addressBook, name)
+}
+
+// lookupAddressBook returns the address book entry for name, ignoring case
+func lookupAddressBook(addressBook map[string]string, name string) string {
+	for bookName, addr := range addressBook {
+		if strings.EqualFold(bookName, name) {
+			return addr
+		}
+	}
 	return ""
 }
 
@@ -339,64 +547,263 @@ func findDeploymentRecord(deployments []DeploymentRecord, name string) *Deployme
 	return nil
 }
 
-func ExecutePostDeployment(contract ContractConfig, contractAddress string, deployments []DeploymentRecord, rpcURL, privateKey string) error {
+// ActionResult records the outcome of a single post-deployment action
+type ActionResult struct {
+	Label  string `json:"label"`
+	Target string `json:"target"`
+	Method string `json:"method"`
+	TxHash string `json:"tx_hash,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ExecutePostDeployment runs the contract's initialize and post-deployment actions in order,
+// returning a result (with tx hash) for every action attempted
+func ExecutePostDeployment(ctx context.Context, contract ContractConfig, contractAddress string, deployments []DeploymentRecord, addressBook map[string]string, rpcURL, token, privateKey string) ([]ActionResult, error) {
 	if contract.PostDeployment == nil {
-		return nil
+		return nil, nil
 	}
 
-	if contract.PostDeployment.Initialize != nil {
-		if err := executeAction(contract, contractAddress, *contract.PostDeployment.Initialize, deployments, rpcURL, privateKey); err != nil {
-			return fmt.Errorf("failed to execute initialize: %w", err)
+	var results []ActionResult
+
+	if init := contract.PostDeployment.Initialize; init != nil {
+		fmt.Printf("Post-deployment initialize: %s\n", init.label())
+		result, err := executeAction(ctx, contract, contractAddress, *init, deployments, addressBook, rpcURL, token, privateKey, nil)
+		results = append(results, result)
+		if err != nil {
+			if !init.ContinueOnError {
+				return results, fmt.Errorf("failed to execute initialize: %w", err)
+			}
+			fmt.Printf("Warning: initialize %q failed, continuing: %v\n", init.label(), err)
 		}
 	}
 
-	for _, action := range contract.PostDeployment.Actions {
-		if err := executeAction(contract, contractAddress, action, deployments, rpcURL, privateKey); err != nil {
-			return fmt.Errorf("failed to execute action %s: %w", action.Method, err)
+	for i, action := range contract.PostDeployment.Actions {
+		fmt.Printf("Post-deployment action %d/%d: %s\n", i+1, len(contract.PostDeployment.Actions), action.label())
+		result, err := executeAction(ctx, contract, contractAddress, action, deployments, addressBook, rpcURL, token, privateKey, nil)
+		results = append(results, result)
+		if err != nil {
+			if action.ContinueOnError {
+				fmt.Printf("Warning: action %q failed, continuing: %v\n", action.label(), err)
+				continue
+			}
+			return results, fmt.Errorf("failed to execute action %q: %w", action.label(), err)
 		}
 	}
 
-	return nil
+	return results, nil
 }
 
-func executeAction(contract ContractConfig, contractAddress string, action PostDeploymentAction, deployments []DeploymentRecord, rpcURL, privateKey string) error {
-	resolvedArgs, err := ResolveDependencies(ContractConfig{ConstructorArgs: action.Args}, deployments)
+// ExecutePostDeploymentBatch runs initialize and waits for it, then submits every action
+// without waiting and waits for all their receipts concurrently. Each action is estimated
+// before the earlier ones are mined, so actions must not depend on each other's state changes.
+func ExecutePostDeploymentBatch(ctx context.Context, contract ContractConfig, contractAddress string, deployments []DeploymentRecord, addressBook map[string]string, rpcURL, token, privateKey string) ([]ActionResult, error) {
+	if contract.PostDeployment == nil {
+		return nil, nil
+	}
+
+	results, err := ExecutePostDeployment(ctx, ContractConfig{
+		Name:           contract.Name,
+		PostDeployment: &PostDeployment{Initialize: contract.PostDeployment.Initialize},
+	}, contractAddress, deployments, addressBook, rpcURL, token, privateKey)
+	if err != nil {
+		return results, err
+	}
+
+	actions := contract.PostDeployment.Actions
+	if len(actions) == 0 {
+		return results, nil
+	}
+
+	client, err := DialEthClient(rpcURL, token)
 	if err != nil {
-		return fmt.Errorf("failed to resolve action args: %w", err)
+		return results, fmt.Errorf("failed to connect to RPC: %w", err)
 	}
+	defer client.Close()
 
-	fmt.Printf("Calling %s.%s() with args: %v\n", contract.Name, action.Method, resolvedArgs)
+	key, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return results, fmt.Errorf("failed to parse private key: %w", err)
+	}
 
-	return callContractMethod(contractAddress, action.Method, resolvedArgs, action.Types, rpcURL, privateKey)
+	// The nonce is tracked locally: the node's pending nonce may not reflect transactions
+	// submitted a moment ago, and reusing one would replace an earlier action
+	nonce, err := client.PendingNonceAt(ctx, crypto.PubkeyToAddress(key.PublicKey))
+	if err != nil {
+		return results, fmt.Errorf("failed to get nonce: %w", err)
+	}
+
+	var hashes []common.Hash
+	var submitted []int // index into results for each hash
+	var submittedActions []PostDeploymentAction
+	var submitErr error
+	for i, action := range actions {
+		fmt.Printf("Submitting post-deployment action %d/%d: %s\n", i+1, len(actions), action.label())
+		result, err := executeAction(ctx, contract, contractAddress, action, deployments, addressBook, rpcURL, token, privateKey, &nonce)
+		results = append(results, result)
+		if err != nil {
+			if action.ContinueOnError {
+				fmt.Printf("Warning: action %q failed, continuing: %v\n", action.label(), err)
+				continue
+			}
+			// Stop submitting, but still wait for what is already in flight
+			submitErr = fmt.Errorf("failed to submit action %q: %w", action.label(), err)
+			break
+		}
+		hashes = append(hashes, common.HexToHash(result.TxHash))
+		submitted = append(submitted, len(results)-1)
+		submittedActions = append(submittedActions, action)
+	}
+
+	if len(hashes) == 0 {
+		return results, submitErr
+	}
+
+	fmt.Printf("Waiting for %d post-deployment transaction(s)...\n", len(hashes))
+	receipts := WaitForReceipts(ctx, client, hashes)
+
+	var failed []string
+	for j, receipt := range receipts {
+		r := &results[submitted[j]]
+		switch {
+		case receipt.Confirmed():
+			fmt.Printf("  Confirmed: %s (%s)\n", r.Label, r.TxHash)
+		case receipt.Reverted():
+			r.Error = "transaction reverted"
+			fmt.Printf("  Reverted:  %s (%s)\n", r.Label, r.TxHash)
+		default:
+			r.Error = fmt.Sprintf("no receipt: %v", receipt.Err)
+			fmt.Printf("  Unconfirmed: %s (%s): %v\n", r.Label, r.TxHash, receipt.Err)
+		}
+		if r.Error != "" && !submittedActions[j].ContinueOnError {
+			failed = append(failed, r.Label)
+		}
+	}
+
+	if submitErr != nil {
+		return results, submitErr
+	}
+	if len(failed) > 0 {
+		return results, fmt.Errorf("%d post-deployment action(s) did not confirm: %s", len(failed), strings.Join(failed, ", "))
+	}
+	return results, nil
+}
+
+// label returns the action description, falling back to the method name
+func (a PostDeploymentAction) label() string {
+	if a.Description != "" {
+		return a.Description
+	}
+	return a.Method
+}
+
+// executeAction resolves and sends one action. With nonce set the transaction is only
+// submitted, using and then advancing *nonce; otherwise it is sent and waited for.
+func executeAction(ctx context.Context, contract ContractConfig, contractAddress string, action PostDeploymentAction, deployments []DeploymentRecord, addressBook map[string]string, rpcURL, token, privateKey string, nonce *uint64) (ActionResult, error) {
+	result := ActionResult{
+		Label:  action.label(),
+		Target: contract.Name,
+		Method: action.Method,
+	}
+
+	fail := func(err error) (ActionResult, error) {
+		result.Error = err.Error()
+		return result, err
+	}
+
+	resolvedArgs, err := ResolveDependencies(ContractConfig{ConstructorArgs: action.Args}, deployments, addressBook)
+	if err != nil {
+		return fail(fmt.Errorf("failed to resolve action args: %w", err))
+	}
+	for i, arg := range resolvedArgs {
+		if resolvedArgs[i], err = resolveSignTypedPlaceholders(arg, deployments, addressBook, privateKey); err != nil {
+			return fail(fmt.Errorf("failed to sign typed data: %w", err))
+		}
+	}
+
+	// Actions default to the contract being deployed, but may target any deployed contract
+	targetAddress := contractAddress
+	if action.Target != "" && !strings.EqualFold(action.Target, "self") {
+		targetAddress, err = resolveExportValue(action.Target, contract.Name, deployments, addressBook)
+		if err != nil {
+			return fail(fmt.Errorf("failed to resolve action target %s: %w", action.Target, err))
+		}
+		result.Target = action.Target
+	}
+
+	fmt.Printf("Calling %s.%s() with args: %v\n", result.Target, action.Method, resolvedArgs)
+
+	txHash, err := callContractMethod(ctx, targetAddress, action.Method, resolvedArgs, action.Types, addressBook, rpcURL, token, privateKey, nonce)
+	if err != nil {
+		return fail(err)
+	}
+	result.TxHash = txHash
+
+	return result, nil
 }
 
-func callContractMethod(contractAddress, methodName string, args []string, types []string, rpcURL, privateKey string) error {
-	convertedArgs, err := convertArguments(args, types)
+func callContractMethod(ctx context.Context, contractAddress, methodName string, args []string, types []string, addressBook map[string]string, rpcURL, token, privateKey string, nonce *uint64) (string, error) {
+	convertedArgs, err := convertArguments(args, types, addressBook)
 	if err != nil {
-		return fmt.Errorf("failed to convert arguments: %w", err)
+		return "", fmt.Errorf("failed to convert arguments: %w", err)
 	}
 
-	wrapper, err := NewContractWrapper(rpcURL, contractAddress)
+	wrapper, err := NewContractWrapper(rpcURL, token, contractAddress)
 	if err != nil {
-		return fmt.Errorf("failed to create contract wrapper: %w", err)
+		return "", fmt.Errorf("failed to create contract wrapper: %w", err)
 	}
 	defer wrapper.Close()
 
 	privateKeyECDSA, err := parsePrivateKey(privateKey)
 	if err != nil {
-		return fmt.Errorf("failed to parse private key: %w", err)
+		return "", fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	if nonce != nil {
+		callData, err := wrapper.PackCall(methodName, convertedArgs)
+		if err != nil {
+			return "", fmt.Errorf("failed to build call data: %w", err)
+		}
+		tx, err := wrapper.SubmitCallDataWithNonce(ctx, callData, nil, privateKeyECDSA, *nonce, 0, nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to send transaction: %w", err)
+		}
+		*nonce++
+		fmt.Printf("Post-deployment action submitted - TX: %s\n", tx.Hash().Hex())
+		return tx.Hash().Hex(), nil
 	}
 
-	tx, err := wrapper.SendTransaction(methodName, convertedArgs, privateKeyECDSA, 0)
+	tx, err := wrapper.SendTransaction(ctx, methodName, convertedArgs, privateKeyECDSA, 0, nil)
 	if err != nil {
-		return fmt.Errorf("failed to send transaction: %w", err)
+		return "", fmt.Errorf("failed to send transaction: %w", err)
 	}
 
 	fmt.Printf("Post-deployment action completed - TX: %s\n", tx.Hash().Hex())
-	return nil
+	return tx.Hash().Hex(), nil
+}
+
+// SavePostDeploymentResults records a contract's post-deployment action results in the
+// given JSON file (contract name -> results), replacing any earlier entry for that contract
+func SavePostDeploymentResults(path, contractName string, results []ActionResult) error {
+	all := make(map[string][]ActionResult)
+	if data, err := ioutil.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &all); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+
+	all[contractName] = results
+
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal post-deployment results: %w", err)
+	}
+
+	return ioutil.WriteFile(path, data, 0644)
 }
 
-func convertArguments(args, types []string) ([]interface{}, error) {
+// convertArguments converts action arguments to their declared types. An address argument
+// that is not a hex address is looked up by name in the address book.
+func convertArguments(args, types []string, addressBook map[string]string) ([]interface{}, error) {
 	if len(args) != len(types) {
 		return nil, fmt.Errorf("argument count mismatch")
 	}
@@ -404,7 +811,13 @@ func convertArguments(args, types []string) ([]interface{}, error) {
 	converted := make([]interface{}, len(args))
 	for i, arg := range args {
 		argType := types[i]
-		convertedArg, err := convertArgument(arg, argType)
+		if strings.EqualFold(argType, "address") && !common.IsHexAddress(arg) {
+			if addr := lookupAddressBook(addressBook, arg); addr != "" {
+				arg = addr
+			}
+		}
+		// Typed so the method signature carries the declared type (bytes32, uint64, ...)
+		convertedArg, err := ConvertTypedArgument(arg, argType)
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert arg %d: %w", i, err)
 		}
@@ -414,10 +827,87 @@ func convertArguments(args, types []string) ([]interface{}, error) {
 	return converted, nil
 }
 
+// TypedArg is a call argument with an explicit Solidity type, used instead of
+// the type inferred from its Go value when building the selector and encoding
+type TypedArg struct {
+	Type  string
+	Value interface{}
+}
+
+// ConvertTypedArgument converts arg to the given Solidity type and keeps that type for ABI encoding
+func ConvertTypedArgument(arg, argType string) (TypedArg, error) {
+	if isTupleType(argType) {
+		return convertTupleArgument(arg, argType)
+	}
+	if isArrayType(argType) {
+		return convertArrayArgument(arg, argType)
+	}
+
+	value, err := convertArgument(arg, argType)
+	if err != nil {
+		return TypedArg{}, err
+	}
+	return TypedArg{Type: canonicalABIType(argType), Value: value}, nil
+}
+
+// IsSolidityType reports whether t names an elementary, tuple, or array type supported by
+// ConvertTypedArgument
+func IsSolidityType(t string) bool {
+	if isTupleType(t) {
+		return true
+	}
+	if open := strings.LastIndex(t, "["); open > 0 && isArrayType(t) {
+		return IsSolidityType(t[:open])
+	}
+	t = strings.ToLower(t)
+	switch t {
+	case "address", "bool", "string", "bytes", "uint", "int":
+		return true
+	}
+	if _, ok := sizedType(t, "uint", 8, 256); ok {
+		return true
+	}
+	if _, ok := sizedType(t, "int", 8, 256); ok {
+		return true
+	}
+	_, ok := sizedType(t, "bytes", 1, 32)
+	return ok
+}
+
+// canonicalABIType maps aliases such as uint and address_from_private_key to their ABI type names
+func canonicalABIType(argType string) string {
+	switch t := strings.ToLower(argType); t {
+	case "uint":
+		return "uint256"
+	case "int":
+		return "int256"
+	case "address_from_private_key", "privatekey_address", "address-private-key":
+		return "address"
+	default:
+		return t
+	}
+}
+
+// sizedType parses the size suffix of types like uint64 or bytes32; a bare prefix has no size
+func sizedType(t, prefix string, step, max int) (int, bool) {
+	if !strings.HasPrefix(t, prefix) {
+		return 0, false
+	}
+	size, err := strconv.Atoi(t[len(prefix):])
+	if err != nil || size <= 0 || size > max || size%step != 0 {
+		return 0, false
+	}
+	return size, true
+}
+
 func convertArgument(arg, argType string) (interface{}, error) {
 	switch strings.ToLower(argType) {
 	case "address":
-		return common.HexToAddress(arg), nil
+		addr, err := ParseAddress(arg)
+		if err != nil {
+			return nil, err
+		}
+		return addr, nil
 	case "address_from_private_key", "privatekey_address", "address-private-key":
 		pk, err := parsePrivateKey(arg)
 		if err != nil {
@@ -426,11 +916,7 @@ func convertArgument(arg, argType string) (interface{}, error) {
 		addr := crypto.PubkeyToAddress(pk.PublicKey)
 		return addr, nil
 	case "uint256", "uint":
-		value, ok := new(big.Int).SetString(arg, 0)
-		if !ok {
-			return nil, fmt.Errorf("invalid uint value: %s", arg)
-		}
-		return value, nil
+		return parseSizedInt(arg, 256, false)
 	case "uint64":
 		if strings.HasPrefix(arg, "0x") {
 			val, err := strconv.ParseUint(arg[2:], 16, 64)
@@ -466,11 +952,54 @@ func convertArgument(arg, argType string) (interface{}, error) {
 			return common.FromHex(arg), nil
 		}
 		return []byte(arg), nil
+	case "int", "int256":
+		return parseSizedInt(arg, 256, true)
 	default:
+		t := strings.ToLower(argType)
+		if bits, ok := sizedType(t, "uint", 8, 256); ok {
+			return parseSizedInt(arg, bits, false)
+		}
+		if bits, ok := sizedType(t, "int", 8, 256); ok {
+			return parseSizedInt(arg, bits, true)
+		}
+		if size, ok := sizedType(t, "bytes", 1, 32); ok {
+			// Hex is taken as raw bytes; anything else is a short string (e.g. a bytes32 name),
+			// right-padded with zeros when encoded
+			data := []byte(arg)
+			if strings.HasPrefix(arg, "0x") {
+				data = common.FromHex(arg)
+			}
+			if len(data) > size {
+				return nil, fmt.Errorf("%s value is %d bytes, want at most %d", t, len(data), size)
+			}
+			return data, nil
+		}
 		return nil, fmt.Errorf("unsupported type: %s", argType)
 	}
 }
 
+// parseSizedInt parses a decimal or 0x-hex integer and checks it fits in a (u)int of the given bit size
+func parseSizedInt(arg string, bits int, signed bool) (*big.Int, error) {
+	value, ok := new(big.Int).SetString(arg, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer value: %s", arg)
+	}
+
+	lower, upper := big.NewInt(0), new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	if signed {
+		upper.Rsh(upper, 1)
+		lower.Neg(upper)
+	}
+	if value.Cmp(lower) < 0 || value.Cmp(upper) >= 0 {
+		kind := "uint"
+		if signed {
+			kind = "int"
+		}
+		return nil, fmt.Errorf("value %s out of range for %s%d", arg, kind, bits)
+	}
+	return value, nil
+}
+
 func parsePrivateKey(privateKeyStr string) (*ecdsa.PrivateKey, error) {
 	privateKeyStr = strings.TrimPrefix(privateKeyStr, "0x")
 
@@ -507,7 +1036,7 @@ func (c *ContractsConfig) SetEnvironmentVariables(contractName string, deploymen
 			os.Setenv(key, resolvedValue)
 		}
 		for exportName, target := range contract.Exports {
-			if resolvedValue, err := resolveExportValue(target, contractName, deployments); err == nil {
+			if resolvedValue, err := resolveExportValue(target, contractName, deployments, c.AddressBook); err == nil {
 				os.Setenv(exportName, resolvedValue)
 			}
 		}
@@ -528,7 +1057,7 @@ func (c *ContractsConfig) ResolveAddressPlaceholders(value string) string {
 
 // ResolveAddressPlaceholdersWithDeployments resolves {address:ContractName} placeholders using deployment records
 func (c *ContractsConfig) ResolveAddressPlaceholdersWithDeployments(value string, deployments []DeploymentRecord) string {
-	return resolveAddressPlaceholders(value, deployments)
+	return resolveAddressPlaceholders(value, deployments, c.AddressBook)
 }
 
 // UpdateEnvironmentWithDeployments updates environment variables with resolved contract addresses
@@ -545,7 +1074,7 @@ func (c *ContractsConfig) UpdateEnvironmentWithDeployments(contractName string,
 
 	if contract := c.GetContractByName(contractName); contract != nil && len(contract.Exports) > 0 {
 		for exportName, target := range contract.Exports {
-			resolvedValue, err := resolveExportValue(target, contractName, deployments)
+			resolvedValue, err := resolveExportValue(target, contractName, deployments, c.AddressBook)
 			if err != nil {
 				fmt.Printf("  Warning: failed to resolve export %s for %s: %v\n", exportName, contractName, err)
 				continue
@@ -588,7 +1117,7 @@ func (c *ContractsConfig) GetContractByName(contractName string) *ContractConfig
 	return nil
 }
 
-func resolveExportValue(target string, currentContract string, deployments []DeploymentRecord) (string, error) {
+func resolveExportValue(target string, currentContract string, deployments []DeploymentRecord, addressBook map[string]string) (string, error) {
 	trimmed := strings.TrimSpace(target)
 	if trimmed == "" {
 		return "", fmt.Errorf("empty export target")
@@ -599,7 +1128,7 @@ func resolveExportValue(target string, currentContract string, deployments []Dep
 	}
 
 	if strings.Contains(trimmed, "{address:") {
-		resolved := resolveAddressPlaceholders(trimmed, deployments)
+		resolved := resolveAddressPlaceholders(trimmed, deployments, addressBook)
 		if strings.Contains(resolved, "{address:") {
 			return "", fmt.Errorf("unresolved address placeholder: %s", target)
 		}
@@ -610,7 +1139,7 @@ func resolveExportValue(target string, currentContract string, deployments []Dep
 		return common.HexToAddress(trimmed).Hex(), nil
 	}
 
-	address := findContractAddress(trimmed, deployments)
+	address := findContractAddress(trimmed, deployments, addressBook)
 	if address == "" {
 		return "", fmt.Errorf("contract %s not found in deployments", trimmed)
 	}
@@ -0,0 +1,400 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestConvertArgument(t *testing.T) {
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+	tests := []struct {
+		name    string
+		arg     string
+		argType string
+		want    interface{}
+		wantErr string
+	}{
+		{name: "uint256 decimal", arg: "42", argType: "uint256", want: big.NewInt(42)},
+		{name: "uint256 hex", arg: "0x2a", argType: "uint256", want: big.NewInt(42)},
+		{name: "uint alias", arg: "1", argType: "uint", want: big.NewInt(1)},
+		{name: "uint256 max", arg: maxUint256.String(), argType: "uint256", want: maxUint256},
+		{name: "uint256 overflow", arg: new(big.Int).Add(maxUint256, big.NewInt(1)).String(), argType: "uint256", wantErr: "out of range for uint256"},
+		{name: "uint256 negative", arg: "-1", argType: "uint256", wantErr: "out of range for uint256"},
+		{name: "uint256 not a number", arg: "ten", argType: "uint256", wantErr: "invalid integer value"},
+		{name: "uint8 overflow", arg: "256", argType: "uint8", wantErr: "out of range for uint8"},
+		{name: "int8 min", arg: "-128", argType: "int8", want: big.NewInt(-128)},
+		{name: "int8 underflow", arg: "-129", argType: "int8", wantErr: "out of range for int8"},
+		{name: "address", arg: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", argType: "address", want: common.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")},
+		{name: "address bad checksum", arg: "0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", argType: "address", wantErr: "invalid EIP-55 checksum"},
+		{name: "address not hex", arg: "Token", argType: "address", wantErr: "invalid address"},
+		{name: "bool", arg: "true", argType: "bool", want: true},
+		{name: "bytes32 string", arg: "hello", argType: "bytes32", want: []byte("hello")},
+		{name: "bytes4 hex", arg: "0xdeadbeef", argType: "bytes4", want: []byte{0xde, 0xad, 0xbe, 0xef}},
+		{name: "bytes4 too long", arg: "hello", argType: "bytes4", wantErr: "want at most 4"},
+		{name: "unsupported", arg: "1", argType: "fixed128x18", wantErr: "unsupported type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertArgument(tt.arg, tt.argType)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("convertArgument: %v", err)
+			}
+
+			if want, ok := tt.want.(*big.Int); ok {
+				if n, ok := got.(*big.Int); !ok || n.Cmp(want) != 0 {
+					t.Errorf("got %v, want %v", got, want)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypedArgumentEncoding(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		argType string
+		want    string // hex of the encoded argument
+	}{
+		{
+			name:    "bytes32 string is right-padded",
+			arg:     "hello",
+			argType: "bytes32",
+			want:    "68656c6c6f000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:    "negative int256 is two's complement",
+			arg:     "-1",
+			argType: "int256",
+			want:    strings.Repeat("f", 64),
+		},
+		{
+			name:    "uint64 is left-padded",
+			arg:     "0xff",
+			argType: "uint64",
+			want:    strings.Repeat("0", 62) + "ff",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			arg, err := ConvertTypedArgument(tt.arg, tt.argType)
+			if err != nil {
+				t.Fatalf("ConvertTypedArgument: %v", err)
+			}
+			encoded, err := encodeArguments([]interface{}{arg})
+			if err != nil {
+				t.Fatalf("encodeArguments: %v", err)
+			}
+			want, _ := hex.DecodeString(tt.want)
+			if !bytes.Equal(encoded, want) {
+				t.Errorf("got %x, want %x", encoded, want)
+			}
+		})
+	}
+}
+
+// deploymentOrder is A <- B <- C, with D independent
+func deploymentOrder() []ContractConfig {
+	return []ContractConfig{
+		{Name: "A"},
+		{Name: "B", Dependencies: []string{"A"}},
+		{Name: "C", Dependencies: []string{"B"}},
+		{Name: "D"},
+	}
+}
+
+func contractNames(contracts []ContractConfig) []string {
+	names := make([]string, len(contracts))
+	for i, contract := range contracts {
+		names[i] = contract.Name
+	}
+	return names
+}
+
+func TestFilterDeploymentOrder(t *testing.T) {
+	deployedA := []DeploymentRecord{{Name: "A", Address: "0x0000000000000000000000000000000000000001"}}
+
+	tests := []struct {
+		name        string
+		only, skip  []string
+		deployments []DeploymentRecord
+		addressBook map[string]string
+		want        []string
+		wantErr     string
+	}{
+		{name: "no selection", want: []string{"A", "B", "C", "D"}},
+		{name: "only with selected dependency", only: []string{"a", "B"}, want: []string{"A", "B"}},
+		{name: "only with deployed dependency", only: []string{"B"}, deployments: deployedA, want: []string{"B"}},
+		{name: "only with missing dependency", only: []string{"B"}, wantErr: "B depends on A"},
+		{name: "only with dependency in the address book", only: []string{"B"}, addressBook: map[string]string{"a": "0x0000000000000000000000000000000000000001"}, want: []string{"B"}},
+		{name: "skip leaf", skip: []string{"C"}, want: []string{"A", "B", "D"}},
+		{name: "skip needed dependency", skip: []string{"A"}, wantErr: "B depends on A"},
+		{name: "unknown contract", only: []string{"E"}, wantErr: "contract E not found in config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FilterDeploymentOrder(deploymentOrder(), tt.only, tt.skip, tt.deployments, tt.addressBook)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FilterDeploymentOrder: %v", err)
+			}
+			if names := contractNames(got); !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("got %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestContinueFrom(t *testing.T) {
+	deployedA := []DeploymentRecord{{Name: "A", Address: "0x0000000000000000000000000000000000000001"}}
+
+	tests := []struct {
+		name        string
+		from        string
+		deployments []DeploymentRecord
+		want        []string
+		wantErr     string
+	}{
+		{name: "from first", from: "A", want: []string{"A", "B", "C", "D"}},
+		{name: "earlier dependency deployed", from: "b", deployments: deployedA, want: []string{"B", "C", "D"}},
+		{name: "earlier dependency missing", from: "B", wantErr: "B depends on A, which comes before B"},
+		{name: "unknown contract", from: "E", wantErr: "not in the deployment order"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ContinueFrom(deploymentOrder(), tt.from, tt.deployments, nil)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ContinueFrom: %v", err)
+			}
+			if names := contractNames(got); !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("got %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddressBook(t *testing.T) {
+	const (
+		usdc     = "0x1111111111111111111111111111111111111111"
+		deployed = "0x2222222222222222222222222222222222222222"
+		booked   = "0x3333333333333333333333333333333333333333"
+	)
+	deployments := []DeploymentRecord{{Name: "Registry", Address: deployed}}
+	addressBook := map[string]string{"USDC": usdc, "Registry": booked}
+
+	tests := []struct {
+		name    string
+		arg     string
+		want    string
+		wantErr string
+	}{
+		{name: "placeholder from the address book", arg: "{address:USDC}", want: usdc},
+		{name: "names ignore case", arg: "{address:usdc}", want: usdc},
+		{name: "legacy form from the address book", arg: "${USDC}", want: usdc},
+		{name: "deployments win over the address book", arg: "{address:Registry}", want: deployed},
+		{name: "unknown name", arg: "{address:DAI}", wantErr: "unresolved address placeholder"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveDependencies(ContractConfig{ConstructorArgs: []string{tt.arg}}, deployments, addressBook)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveDependencies: %v", err)
+			}
+			if got[0] != tt.want {
+				t.Errorf("got %s, want %s", got[0], tt.want)
+			}
+		})
+	}
+
+	t.Run("address argument by name", func(t *testing.T) {
+		args, err := convertArguments([]string{"USDC"}, []string{"address"}, addressBook)
+		if err != nil {
+			t.Fatalf("convertArguments: %v", err)
+		}
+		if got := args[0].(TypedArg).Value; got != common.HexToAddress(usdc) {
+			t.Errorf("got %v, want %s", got, usdc)
+		}
+	})
+
+	t.Run("unknown address argument", func(t *testing.T) {
+		if _, err := convertArguments([]string{"DAI"}, []string{"address"}, addressBook); err == nil || !strings.Contains(err.Error(), "invalid address") {
+			t.Errorf("error = %v, want an invalid address error", err)
+		}
+	})
+}
+
+func TestPostDeploymentActionTarget(t *testing.T) {
+	const (
+		vault    = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+		registry = "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359"
+	)
+	deployments := []DeploymentRecord{{Name: "Registry", Address: registry}}
+
+	tests := []struct {
+		name   string
+		target string
+		wantTo string
+	}{
+		{name: "default is the deployed contract", target: "", wantTo: vault},
+		{name: "self", target: "self", wantTo: vault},
+		{name: "another deployed contract", target: "Registry", wantTo: registry},
+		{name: "name is case-insensitive", target: "registry", wantTo: registry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, url := newFakeEthNode(t)
+			contract := ContractConfig{
+				Name: "Vault",
+				PostDeployment: &PostDeployment{Actions: []PostDeploymentAction{
+					{Method: "register", Args: []string{vault}, Types: []string{"address"}, Target: tt.target},
+				}},
+			}
+
+			if _, err := ExecutePostDeployment(context.Background(), contract, vault, deployments, nil, url, "", testKey); err != nil {
+				t.Fatalf("ExecutePostDeployment: %v", err)
+			}
+
+			sent := node.transactions()
+			if len(sent) != 1 {
+				t.Fatalf("sent %d transactions, want 1", len(sent))
+			}
+			if got := sent[0].To().Hex(); got != tt.wantTo {
+				t.Errorf("action sent to %s, want %s", got, tt.wantTo)
+			}
+			want := append(selector("register(address)"), common.LeftPadBytes(common.HexToAddress(vault).Bytes(), 32)...)
+			if !bytes.Equal(sent[0].Data(), want) {
+				t.Errorf("calldata = %x, want %x", sent[0].Data(), want)
+			}
+		})
+	}
+}
+
+func TestPostDeploymentContinueOnError(t *testing.T) {
+	tests := []struct {
+		name            string
+		continueOnError bool
+		wantSent        []string
+		wantErr         bool
+	}{
+		{name: "critical failure aborts", continueOnError: false, wantSent: []string{"setLimit(uint256)"}, wantErr: true},
+		{name: "non-critical failure is skipped", continueOnError: true, wantSent: []string{"setLimit(uint256)", "unpause()"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, url := newFakeEthNode(t)
+			node.failMethod("pause(uint256)")
+
+			contract := ContractConfig{
+				Name: "Vault",
+				PostDeployment: &PostDeployment{Actions: []PostDeploymentAction{
+					{Method: "setLimit", Args: []string{"1"}, Types: []string{"uint256"}},
+					{Method: "pause", Args: []string{"2"}, Types: []string{"uint256"}, Description: "pause briefly", ContinueOnError: tt.continueOnError},
+					{Method: "unpause"},
+				}},
+			}
+
+			results, err := ExecutePostDeployment(context.Background(), contract, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", nil, nil, url, "", testKey)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), `"pause briefly"`) {
+					t.Fatalf("error = %v, want the failed action named by its description", err)
+				}
+			} else if err != nil {
+				t.Fatalf("ExecutePostDeployment: %v", err)
+			}
+			if len(results) < 2 || results[1].Label != "pause briefly" || results[1].Error == "" {
+				t.Errorf("results = %+v, want the second action recorded as failed", results)
+			}
+
+			sent := node.transactions()
+			if len(sent) != len(tt.wantSent) {
+				t.Fatalf("sent %d transactions, want %d", len(sent), len(tt.wantSent))
+			}
+			for i, sig := range tt.wantSent {
+				if !bytes.HasPrefix(sent[i].Data(), selector(sig)) {
+					t.Errorf("transaction %d calls %x, want %s", i, sent[i].Data()[:4], sig)
+				}
+			}
+		})
+	}
+}
+
+func TestExecutePostDeploymentBatchNonces(t *testing.T) {
+	node, url := newFakeEthNode(t)
+	node.failMethod("pause(uint256)")
+
+	const contractAddress = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	contract := ContractConfig{
+		Name: "Vault",
+		PostDeployment: &PostDeployment{Actions: []PostDeploymentAction{
+			{Method: "setLimit", Args: []string{"1"}, Types: []string{"uint256"}},
+			{Method: "pause", Args: []string{"2"}, Types: []string{"uint256"}, ContinueOnError: true},
+			{Method: "setLimit", Args: []string{"3"}, Types: []string{"uint256"}},
+			{Method: "setLimit", Args: []string{"4"}, Types: []string{"uint256"}},
+		}},
+	}
+
+	results, err := ExecutePostDeploymentBatch(context.Background(), contract, contractAddress, nil, nil, url, "", testKey)
+	if err != nil {
+		t.Fatalf("ExecutePostDeploymentBatch: %v", err)
+	}
+	if len(results) != 4 || results[1].Error == "" {
+		t.Fatalf("results = %+v, want 4 with the second failed", results)
+	}
+
+	// The node's pending nonce never advances, so only local tracking gives distinct nonces;
+	// the skipped action must not leave a gap
+	sent := node.transactions()
+	if len(sent) != 3 {
+		t.Fatalf("sent %d transactions, want 3", len(sent))
+	}
+	for i, tx := range sent {
+		if tx.Nonce() != uint64(i) {
+			t.Errorf("transaction %d nonce = %d, want %d", i, tx.Nonce(), i)
+		}
+		if *tx.To() != common.HexToAddress(contractAddress) {
+			t.Errorf("transaction %d sent to %s, want %s", i, tx.To().Hex(), contractAddress)
+		}
+	}
+}
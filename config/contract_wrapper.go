@@ -3,26 +3,29 @@ package config
 import (
 	"context"
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
-	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"golang.org/x/crypto/sha3"
 )
 
 type ContractWrapper struct {
 	client  *ethclient.Client
 	address common.Address
+	block   *big.Int // block read calls run against; nil means latest
 }
 
-func NewContractWrapper(rpcURL, contractAddress string) (*ContractWrapper, error) {
-	client, err := ethclient.Dial(rpcURL)
+func NewContractWrapper(rpcURL, token, contractAddress string) (*ContractWrapper, error) {
+	client, err := DialEthClient(rpcURL, token)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to RPC: %w", err)
 	}
@@ -35,14 +38,14 @@ func NewContractWrapper(rpcURL, contractAddress string) (*ContractWrapper, error
 	}, nil
 }
 
-func (cw *ContractWrapper) CallMethod(methodName string, args []interface{}) ([]byte, error) {
+func (cw *ContractWrapper) CallMethod(ctx context.Context, methodName string, args []interface{}) ([]byte, error) {
 	callData, err := cw.buildCallData(methodName, args)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build call data: %w", err)
 	}
 
 	callMsg := cw.buildCallMsg(callData)
-	result, err := cw.client.CallContract(context.Background(), callMsg, nil)
+	result, err := cw.client.CallContract(ctx, callMsg, cw.block)
 	if err != nil {
 		return nil, fmt.Errorf("contract call failed: %w", err)
 	}
@@ -50,61 +53,257 @@ func (cw *ContractWrapper) CallMethod(methodName string, args []interface{}) ([]
 	return result, nil
 }
 
-func (cw *ContractWrapper) SendTransaction(methodName string, args []interface{}, privateKey *ecdsa.PrivateKey, gasLimit uint64) (*types.Transaction, error) {
+// Simulate runs callData against the wrapped address as an eth_call from the given sender,
+// without sending a transaction. A call that would revert returns its decoded reason.
+func (cw *ContractWrapper) Simulate(ctx context.Context, from common.Address, callData []byte, value *big.Int) error {
+	_, err := cw.client.CallContract(ctx, ethereum.CallMsg{
+		From:  from,
+		To:    &cw.address,
+		Value: value,
+		Data:  callData,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("call would revert: %s", RevertReason(err))
+	}
+	return nil
+}
+
+// RevertReason extracts the Error(string) reason from a failed call, falling back to the
+// error text when the node returned no decodable revert data
+func RevertReason(err error) string {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if reason, unpackErr := abi.UnpackRevert(common.FromHex(data)); unpackErr == nil {
+				return reason
+			}
+		}
+	}
+	return err.Error()
+}
+
+// PackCall builds the calldata for a method call on the wrapped contract
+func (cw *ContractWrapper) PackCall(methodName string, args []interface{}) ([]byte, error) {
+	return cw.buildCallData(methodName, args)
+}
+
+// Address returns the wrapped contract address
+func (cw *ContractWrapper) Address() common.Address {
+	return cw.address
+}
+
+// SetBlock makes read calls run against block (see ParseBlockTag); nil reads at latest
+func (cw *ContractWrapper) SetBlock(block *big.Int) {
+	cw.block = block
+}
+
+// HasCode reports whether any contract code is deployed at the wrapped address
+func (cw *ContractWrapper) HasCode(ctx context.Context) (bool, error) {
+	code, err := cw.client.CodeAt(ctx, cw.address, cw.block)
+	if err != nil {
+		return false, err
+	}
+	return len(code) > 0, nil
+}
+
+// Aggregate runs the given calls through a Multicall3 contract over the wrapper's connection
+func (cw *ContractWrapper) Aggregate(ctx context.Context, multicallAddress string, calls []Call3) ([]Call3Result, error) {
+	return Aggregate3(ctx, cw.client, common.HexToAddress(multicallAddress), calls, cw.block)
+}
+
+// FeeOverrides replaces the node's suggested gas pricing when any field is set.
+// GasPrice produces a legacy transaction; MaxFee/PriorityFee produce an EIP-1559 one.
+type FeeOverrides struct {
+	GasPrice    *big.Int
+	MaxFee      *big.Int
+	PriorityFee *big.Int
+}
+
+func (f *FeeOverrides) dynamic() bool {
+	return f != nil && (f.MaxFee != nil || f.PriorityFee != nil)
+}
+
+func (cw *ContractWrapper) SendTransaction(ctx context.Context, methodName string, args []interface{}, privateKey *ecdsa.PrivateKey, gasLimit uint64, fees *FeeOverrides) (*types.Transaction, error) {
 	callData, err := cw.buildCallData(methodName, args)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build call data: %w", err)
 	}
 
+	return cw.SendCallData(ctx, callData, nil, privateKey, gasLimit, fees)
+}
+
+// SubmitTransaction signs and sends a method call without waiting for it to be mined
+func (cw *ContractWrapper) SubmitTransaction(ctx context.Context, methodName string, args []interface{}, privateKey *ecdsa.PrivateKey, gasLimit uint64, fees *FeeOverrides) (*types.Transaction, error) {
+	callData, err := cw.buildCallData(methodName, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build call data: %w", err)
+	}
+
+	return cw.SubmitCallData(ctx, callData, nil, privateKey, gasLimit, fees)
+}
+
+// SendCallData signs and sends a transaction carrying callData unchanged to the wrapped
+// address, with an optional value, and waits for its receipt
+func (cw *ContractWrapper) SendCallData(ctx context.Context, callData []byte, value *big.Int, privateKey *ecdsa.PrivateKey, gasLimit uint64, fees *FeeOverrides) (*types.Transaction, error) {
+	signedTx, err := cw.SubmitCallData(ctx, callData, value, privateKey, gasLimit, fees)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = cw.waitForTransactionReceipt(ctx, signedTx.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("transaction failed: %w", err)
+	}
+
+	return signedTx, nil
+}
+
+// SendCallDataWithNonce is SendCallData with a caller-chosen nonce
+func (cw *ContractWrapper) SendCallDataWithNonce(ctx context.Context, callData []byte, value *big.Int, privateKey *ecdsa.PrivateKey, nonce, gasLimit uint64, fees *FeeOverrides) (*types.Transaction, error) {
+	signedTx, err := cw.SubmitCallDataWithNonce(ctx, callData, value, privateKey, nonce, gasLimit, fees)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = cw.waitForTransactionReceipt(ctx, signedTx.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("transaction failed: %w", err)
+	}
+
+	return signedTx, nil
+}
+
+// SubmitCallData signs and sends a transaction carrying callData without waiting for it to be mined
+func (cw *ContractWrapper) SubmitCallData(ctx context.Context, callData []byte, value *big.Int, privateKey *ecdsa.PrivateKey, gasLimit uint64, fees *FeeOverrides) (*types.Transaction, error) {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
 	fromAddress := crypto.PubkeyToAddress(privateKey.PublicKey)
 
-	nonce, err := cw.client.PendingNonceAt(context.Background(), fromAddress)
+	nonce, err := cw.client.PendingNonceAt(ctx, fromAddress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get nonce: %w", err)
 	}
 
-	gasPrice, err := cw.client.SuggestGasPrice(context.Background())
+	return cw.SubmitCallDataWithNonce(ctx, callData, value, privateKey, nonce, gasLimit, fees)
+}
+
+// PendingNonce returns the next nonce of from, counting its pending transactions
+func (cw *ContractWrapper) PendingNonce(ctx context.Context, from common.Address) (uint64, error) {
+	nonce, err := cw.client.PendingNonceAt(ctx, from)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get gas price: %w", err)
+		return 0, fmt.Errorf("failed to get nonce: %w", err)
+	}
+	return nonce, nil
+}
+
+// SubmitCallDataWithNonce is SubmitCallData with a caller-managed nonce, so several transactions
+// from one sender can be submitted back to back
+func (cw *ContractWrapper) SubmitCallDataWithNonce(ctx context.Context, callData []byte, value *big.Int, privateKey *ecdsa.PrivateKey, nonce, gasLimit uint64, fees *FeeOverrides) (*types.Transaction, error) {
+	if value == nil {
+		value = big.NewInt(0)
 	}
 
+	fromAddress := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	var err error
 	if gasLimit == 0 {
 		callMsg := ethereum.CallMsg{
-			From: fromAddress,
-			To:   &cw.address,
-			Data: callData,
+			From:  fromAddress,
+			To:    &cw.address,
+			Value: value,
+			Data:  callData,
 		}
-		gasLimit, err = cw.client.EstimateGas(context.Background(), callMsg)
+		gasLimit, err = cw.client.EstimateGas(ctx, callMsg)
 		if err != nil {
 			return nil, fmt.Errorf("failed to estimate gas: %w", err)
 		}
 	}
 
-	tx := types.NewTransaction(nonce, cw.address, big.NewInt(0), gasLimit, gasPrice, callData)
-
-	chainID, err := cw.client.NetworkID(context.Background())
+	chainID, err := cw.client.NetworkID(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get chain ID: %w", err)
 	}
 
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), privateKey)
+	tx, err := cw.buildTransaction(ctx, chainID, nonce, gasLimit, value, callData, fees)
 	if err != nil {
-		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+		return nil, err
 	}
 
-	err = cw.client.SendTransaction(context.Background(), signedTx)
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), privateKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send transaction: %w", err)
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
 	}
 
-	_, err = cw.waitForTransactionReceipt(context.Background(), signedTx.Hash())
+	err = cw.client.SendTransaction(ctx, signedTx)
 	if err != nil {
-		return nil, fmt.Errorf("transaction failed: %w", err)
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
 	}
 
 	return signedTx, nil
 }
 
+// WaitForReceipts waits for the given transactions over the wrapper's connection
+func (cw *ContractWrapper) WaitForReceipts(ctx context.Context, hashes []common.Hash) []ReceiptResult {
+	return WaitForReceipts(ctx, cw.client, hashes)
+}
+
+// buildTransaction creates the unsigned transaction, applying any fee overrides in place of suggestions
+func (cw *ContractWrapper) buildTransaction(ctx context.Context, chainID *big.Int, nonce, gasLimit uint64, value *big.Int, callData []byte, fees *FeeOverrides) (*types.Transaction, error) {
+	if !fees.dynamic() {
+		var gasPrice *big.Int
+		if fees != nil {
+			gasPrice = fees.GasPrice
+		}
+		if gasPrice == nil {
+			var err error
+			gasPrice, err = cw.client.SuggestGasPrice(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get gas price: %w", err)
+			}
+		}
+		return types.NewTransaction(nonce, cw.address, value, gasLimit, gasPrice, callData), nil
+	}
+
+	tipCap := fees.PriorityFee
+	if tipCap == nil {
+		var err error
+		tipCap, err = cw.client.SuggestGasTipCap(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get priority fee: %w", err)
+		}
+	}
+
+	feeCap := fees.MaxFee
+	if feeCap == nil {
+		head, err := cw.client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get base fee: %w", err)
+		}
+		baseFee := head.BaseFee
+		if baseFee == nil {
+			baseFee = big.NewInt(0)
+		}
+		feeCap = new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), tipCap)
+	}
+
+	if feeCap.Cmp(tipCap) < 0 {
+		return nil, fmt.Errorf("max fee %s is lower than priority fee %s", feeCap, tipCap)
+	}
+
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: tipCap,
+		GasFeeCap: feeCap,
+		Gas:       gasLimit,
+		To:        &cw.address,
+		Value:     value,
+		Data:      callData,
+	}), nil
+}
+
 func (cw *ContractWrapper) buildCallData(methodName string, args []interface{}) ([]byte, error) {
 	methodSig := fmt.Sprintf("%s(%s)", methodName, cw.getMethodSignature(args))
 
@@ -117,7 +316,7 @@ func (cw *ContractWrapper) buildCallData(methodName string, args []interface{})
 		return methodSelector, nil
 	}
 
-	encodedArgs, err := cw.encodeArguments(args)
+	encodedArgs, err := encodeArguments(args)
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode arguments: %w", err)
 	}
@@ -129,7 +328,9 @@ func (cw *ContractWrapper) buildCallData(methodName string, args []interface{})
 func (cw *ContractWrapper) getMethodSignature(args []interface{}) string {
 	signatures := make([]string, len(args))
 	for i, arg := range args {
-		switch arg.(type) {
+		switch v := arg.(type) {
+		case TypedArg:
+			signatures[i] = v.Type
 		case common.Address:
 			signatures[i] = "address"
 		case *big.Int:
@@ -138,6 +339,10 @@ func (cw *ContractWrapper) getMethodSignature(args []interface{}) string {
 			signatures[i] = "bool"
 		case string:
 			signatures[i] = "string"
+		case [][]byte:
+			signatures[i] = "bytes[]"
+		case [][32]byte:
+			signatures[i] = "bytes32[]"
 		default:
 			signatures[i] = "bytes"
 		}
@@ -152,88 +357,163 @@ func (cw *ContractWrapper) buildCallMsg(data []byte) ethereum.CallMsg {
 	}
 }
 
-func (cw *ContractWrapper) encodeArguments(args []interface{}) ([]byte, error) {
+func encodeArguments(args []interface{}) ([]byte, error) {
 	var head []byte
 	var tail []byte
-	var dynamicArgs []int
+	var dynamicArgs []int // head offsets of the dynamic arguments' offset words
 	var dynamicData [][]byte
 
-	// First pass: encode static types, collect dynamic types
-	for i, arg := range args {
-		switch v := arg.(type) {
-		case common.Address:
-			padded := make([]byte, 32)
-			copy(padded[12:], v.Bytes())
-			head = append(head, padded...)
-		case *big.Int:
-			padded := make([]byte, 32)
-			bytes := v.Bytes()
-			copy(padded[32-len(bytes):], bytes)
-			head = append(head, padded...)
-		case bool:
-			padded := make([]byte, 32)
-			if v {
-				padded[31] = 1
-			}
-			head = append(head, padded...)
-		case string:
-			dynamicArgs = append(dynamicArgs, i)
+	// First pass: encode static types, collect dynamic types.
+	// Static tuples occupy more than one head word, so positions are tracked in bytes.
+	for _, arg := range args {
+		word, dynamic, err := encodeArgument(arg)
+		if err != nil {
+			return nil, err
+		}
+		if dynamic != nil {
+			dynamicArgs = append(dynamicArgs, len(head))
 			head = append(head, make([]byte, 32)...)
-			strBytes := []byte(v)
-			strLen := len(strBytes)
-			lenBytes := make([]byte, 32)
-			bigLen := big.NewInt(int64(strLen)).Bytes()
-			copy(lenBytes[32-len(bigLen):], bigLen)
-			paddedLen := ((strLen + 31) / 32) * 32
-			paddedData := make([]byte, paddedLen)
-			copy(paddedData, strBytes)
-			dyn := append(lenBytes, paddedData...)
-			dynamicData = append(dynamicData, dyn)
-		default:
-			return nil, fmt.Errorf("unsupported argument type: %T", arg)
+			dynamicData = append(dynamicData, dynamic)
+			continue
 		}
+		head = append(head, word...)
 	}
 
 	// Second pass: fill in offsets for dynamic types and build tail
-	headLen := len(args) * 32
+	headLen := len(head)
 	headWithOffsets := make([]byte, len(head))
 	copy(headWithOffsets, head)
 	tailOffset := headLen
-	dynIdx := 0
-	for i, arg := range args {
-		switch arg.(type) {
-		case string:
-			offsetBytes := make([]byte, 32)
-			bigOffset := big.NewInt(int64(tailOffset)).Bytes()
-			copy(offsetBytes[32-len(bigOffset):], bigOffset)
-			copy(headWithOffsets[i*32:(i+1)*32], offsetBytes)
-			tail = append(tail, dynamicData[dynIdx]...)
-			tailOffset += len(dynamicData[dynIdx])
-			dynIdx++
-		}
+	for dynIdx, pos := range dynamicArgs {
+		offsetBytes := make([]byte, 32)
+		bigOffset := big.NewInt(int64(tailOffset)).Bytes()
+		copy(offsetBytes[32-len(bigOffset):], bigOffset)
+		copy(headWithOffsets[pos:pos+32], offsetBytes)
+		tail = append(tail, dynamicData[dynIdx]...)
+		tailOffset += len(dynamicData[dynIdx])
 	}
 
 	encoded := append(headWithOffsets, tail...)
 	return encoded, nil
 }
 
+// encodeArgument returns either the 32-byte head word of a static argument or the tail data of a dynamic one
+func encodeArgument(arg interface{}) ([]byte, []byte, error) {
+	switch v := arg.(type) {
+	case TypedArg:
+		return encodeTypedArgument(v)
+	case common.Address:
+		padded := make([]byte, 32)
+		copy(padded[12:], v.Bytes())
+		return padded, nil, nil
+	case *big.Int:
+		if v.Sign() < 0 || v.BitLen() > 256 {
+			return nil, nil, fmt.Errorf("value %s out of range for uint256", v)
+		}
+		padded := make([]byte, 32)
+		bytes := v.Bytes()
+		copy(padded[32-len(bytes):], bytes)
+		return padded, nil, nil
+	case bool:
+		padded := make([]byte, 32)
+		if v {
+			padded[31] = 1
+		}
+		return padded, nil, nil
+	case string:
+		return nil, encodeDynamicBytes([]byte(v)), nil
+	case []byte:
+		return nil, encodeDynamicBytes(v), nil
+	case [][]byte:
+		return nil, encodeBytesArray(v), nil
+	case [][32]byte:
+		data := encodeLength(len(v))
+		for _, word := range v {
+			data = append(data, word[:]...)
+		}
+		return nil, data, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported argument type: %T", arg)
+	}
+}
+
+// encodeTypedArgument encodes a value according to its declared Solidity type
+func encodeTypedArgument(arg TypedArg) ([]byte, []byte, error) {
+	switch v := arg.Value.(type) {
+	case *big.Int:
+		if strings.HasPrefix(arg.Type, "int") && v.Sign() < 0 {
+			// Two's complement over 256 bits
+			word := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 256), v).Bytes()
+			return word, nil, nil
+		}
+		return encodeArgument(v)
+	case EncodedValue:
+		if v.Dynamic {
+			return nil, v.Encoded, nil
+		}
+		return v.Encoded, nil, nil
+	case []byte:
+		if arg.Type == "bytes" {
+			return encodeArgument(v)
+		}
+		// Fixed-size bytesN values are right-padded into a single word
+		padded := make([]byte, 32)
+		copy(padded, v)
+		return padded, nil, nil
+	default:
+		return encodeArgument(v)
+	}
+}
+
+// encodeDynamicBytes encodes a length-prefixed, right-padded dynamic value
+func encodeDynamicBytes(data []byte) []byte {
+	paddedLen := ((len(data) + 31) / 32) * 32
+	paddedData := make([]byte, paddedLen)
+	copy(paddedData, data)
+	return append(encodeLength(len(data)), paddedData...)
+}
+
+// encodeBytesArray encodes a bytes[] value: the element count, one offset word per element
+// (relative to the first offset word), then each element as dynamic bytes
+func encodeBytesArray(elems [][]byte) []byte {
+	head := encodeLength(len(elems))
+	var tail []byte
+	for _, elem := range elems {
+		head = append(head, encodeLength(32*len(elems)+len(tail))...)
+		tail = append(tail, encodeDynamicBytes(elem)...)
+	}
+	return append(head, tail...)
+}
+
+// encodeLength encodes a length or offset as a 32-byte word
+func encodeLength(n int) []byte {
+	word := make([]byte, 32)
+	b := big.NewInt(int64(n)).Bytes()
+	copy(word[32-len(b):], b)
+	return word
+}
+
 func (cw *ContractWrapper) waitForTransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
-	for i := 0; i < 60; i++ {
+	receipt, err := PollReceipt(ctx, func(ctx context.Context) (*types.Receipt, bool, error) {
 		receipt, err := cw.client.TransactionReceipt(ctx, txHash)
-		if err == nil && receipt != nil {
-			if receipt.Status == 1 {
-				fmt.Printf("Transaction confirmed: %s\n", txHash.Hex())
-				return receipt, nil
-			} else {
-				return nil, fmt.Errorf("transaction failed: %s", txHash.Hex())
-			}
+		if errors.Is(err, ethereum.NotFound) || (err == nil && receipt == nil) {
+			fmt.Printf("Waiting for transaction confirmation... %s\n", txHash.Hex())
+			return nil, false, nil
 		}
-
-		fmt.Printf("Waiting for transaction confirmation... %s\n", txHash.Hex())
-		time.Sleep(1 * time.Second)
+		if err != nil {
+			return nil, false, err
+		}
+		return receipt, true, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, fmt.Errorf("transaction not confirmed after waiting")
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction failed: %s", txHash.Hex())
+	}
+	fmt.Printf("Transaction confirmed: %s\n", txHash.Hex())
+	return receipt, nil
 }
 
 func (cw *ContractWrapper) Close() {
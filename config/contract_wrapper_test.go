@@ -0,0 +1,193 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// packWithABI encodes values with go-ethereum's ABI packer, as the reference encoding
+func packWithABI(t *testing.T, types []string, values []interface{}) []byte {
+	t.Helper()
+
+	var args abi.Arguments
+	for _, typeName := range types {
+		typ, err := abi.NewType(typeName, "", nil)
+		if err != nil {
+			t.Fatalf("abi.NewType(%s): %v", typeName, err)
+		}
+		args = append(args, abi.Argument{Type: typ})
+	}
+	packed, err := args.Pack(values...)
+	if err != nil {
+		t.Fatalf("abi Pack: %v", err)
+	}
+	return packed
+}
+
+func TestEncodeArguments(t *testing.T) {
+	addr := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	short := []byte{0x01, 0x02}
+	long := bytes.Repeat([]byte{0xff}, 33)
+	var word1, word2 [32]byte
+	word1[0], word2[31] = 0x11, 0x22
+
+	tests := []struct {
+		name   string
+		args   []interface{}
+		types  []string
+		values []interface{}
+	}{
+		{
+			name:   "static",
+			args:   []interface{}{addr, big.NewInt(42), true},
+			types:  []string{"address", "uint256", "bool"},
+			values: []interface{}{addr, big.NewInt(42), true},
+		},
+		{
+			name:   "string and bytes",
+			args:   []interface{}{"hello", []byte{0xde, 0xad}},
+			types:  []string{"string", "bytes"},
+			values: []interface{}{"hello", []byte{0xde, 0xad}},
+		},
+		{
+			name:   "bytes array with elements of different lengths",
+			args:   []interface{}{[][]byte{short, long}},
+			types:  []string{"bytes[]"},
+			values: []interface{}{[][]byte{short, long}},
+		},
+		{
+			name:   "empty bytes array",
+			args:   []interface{}{[][]byte{}},
+			types:  []string{"bytes[]"},
+			values: []interface{}{[][]byte{}},
+		},
+		{
+			name:   "bytes32 array",
+			args:   []interface{}{[][32]byte{word1, word2}},
+			types:  []string{"bytes32[]"},
+			values: []interface{}{[][32]byte{word1, word2}},
+		},
+		{
+			name:   "static between dynamic",
+			args:   []interface{}{[][]byte{short}, big.NewInt(7), "x"},
+			types:  []string{"bytes[]", "uint256", "string"},
+			values: []interface{}{[][]byte{short}, big.NewInt(7), "x"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodeArguments(tt.args)
+			if err != nil {
+				t.Fatalf("encodeArguments: %v", err)
+			}
+			want := packWithABI(t, tt.types, tt.values)
+			if !bytes.Equal(got, want) {
+				t.Errorf("encoding mismatch\n got: %x\nwant: %x", got, want)
+			}
+		})
+	}
+}
+
+func TestEncodeArgumentsRejectsUnencodable(t *testing.T) {
+	tooBig := new(big.Int).Lsh(big.NewInt(1), 256)
+
+	tests := []struct {
+		name    string
+		arg     interface{}
+		wantErr string
+	}{
+		{name: "uint256 overflow", arg: tooBig, wantErr: "out of range for uint256"},
+		{name: "negative uint256", arg: big.NewInt(-1), wantErr: "out of range for uint256"},
+		{name: "unsupported Go type", arg: 3.5, wantErr: "unsupported argument type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := encodeArguments([]interface{}{tt.arg})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("encodeArguments error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetMethodSignature(t *testing.T) {
+	cw := &ContractWrapper{}
+
+	tests := []struct {
+		name string
+		args []interface{}
+		want string
+	}{
+		{name: "inferred", args: []interface{}{common.Address{}, big.NewInt(1), true, "s", []byte{1}}, want: "address,uint256,bool,string,bytes"},
+		{name: "byte arrays", args: []interface{}{[][]byte{{1}}, [][32]byte{{}}}, want: "bytes[],bytes32[]"},
+		{name: "typed", args: []interface{}{TypedArg{Type: "uint64", Value: big.NewInt(1)}}, want: "uint64"},
+		{name: "none", args: nil, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cw.getMethodSignature(tt.args); got != tt.want {
+				t.Errorf("getMethodSignature = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildCallDataSelector(t *testing.T) {
+	cw := &ContractWrapper{}
+
+	// transfer(address,uint256) is the well-known ERC-20 selector a9059cbb
+	callData, err := cw.buildCallData("transfer", []interface{}{common.Address{}, big.NewInt(1)})
+	if err != nil {
+		t.Fatalf("buildCallData: %v", err)
+	}
+	if got := hex.EncodeToString(callData[:4]); got != "a9059cbb" {
+		t.Errorf("selector = %s, want a9059cbb", got)
+	}
+	if len(callData) != 4+64 {
+		t.Errorf("calldata is %d bytes, want %d", len(callData), 4+64)
+	}
+}
+
+func TestSendTransactionAbortsWhenCancelled(t *testing.T) {
+	node, url := newFakeEthNode(t)
+	node.unmined = true
+
+	wrapper, err := NewContractWrapper(url, "", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	if err != nil {
+		t.Fatalf("NewContractWrapper: %v", err)
+	}
+	defer wrapper.Close()
+
+	key, err := parsePrivateKey(testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Cancel while the transaction is waiting to be mined, as Ctrl+C cancels the root context
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(200*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err = wrapper.SendTransaction(ctx, "pause", nil, key, 0, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("SendTransaction returned %s after cancellation", elapsed)
+	}
+	if len(node.transactions()) != 1 {
+		t.Errorf("sent %d transactions, want 1", len(node.transactions()))
+	}
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// ParseTypedData parses an EIP-712 typed-data document as accepted by eth_signTypedData_v4
+func ParseTypedData(data []byte) (apitypes.TypedData, error) {
+	var typedData apitypes.TypedData
+	if err := json.Unmarshal(data, &typedData); err != nil {
+		return apitypes.TypedData{}, fmt.Errorf("failed to parse typed data: %w", err)
+	}
+	return typedData, nil
+}
+
+// SignTypedData returns the 65-byte EIP-712 signature r || s || v over typedData, with v as 27
+// or 28 the way contracts using ecrecover expect it
+func SignTypedData(privateKey *ecdsa.PrivateKey, typedData apitypes.TypedData) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash typed data: %w", err)
+	}
+
+	sig, err := crypto.Sign(hash, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign typed data: %w", err)
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+// resolveSignTypedPlaceholders replaces {sign-typed:path} with the signer's EIP-712 signature
+// over the typed-data file at path, and {sign-typed:path:v}, {sign-typed:path:r}, and
+// {sign-typed:path:s} with one component of it, for methods such as permit that take v, r, s
+// separately. {address:ContractName} placeholders in the file are resolved before signing.
+func resolveSignTypedPlaceholders(input string, deployments []DeploymentRecord, addressBook map[string]string, privateKey string) (string, error) {
+	result := input
+
+	for {
+		start := strings.Index(result, "{sign-typed:")
+		if start == -1 {
+			break
+		}
+
+		end := strings.Index(result[start:], "}")
+		if end == -1 {
+			break
+		}
+		end += start
+
+		placeholder := result[start : end+1]
+		path := placeholder[len("{sign-typed:") : len(placeholder)-1]
+		part := ""
+		if i := strings.LastIndex(path, ":"); i != -1 {
+			switch suffix := path[i+1:]; suffix {
+			case "v", "r", "s":
+				path, part = path[:i], suffix
+			}
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read typed data %s: %w", path, err)
+		}
+		resolved := resolveAddressPlaceholders(string(data), deployments, addressBook)
+		if strings.Contains(resolved, "{address:") {
+			return "", fmt.Errorf("unresolved address placeholder in typed data %s", path)
+		}
+
+		typedData, err := ParseTypedData([]byte(resolved))
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", path, err)
+		}
+		key, err := parsePrivateKey(privateKey)
+		if err != nil {
+			return "", fmt.Errorf("invalid signer key for %s: %w", placeholder, err)
+		}
+		sig, err := SignTypedData(key, typedData)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", path, err)
+		}
+
+		var value string
+		switch part {
+		case "v":
+			value = fmt.Sprintf("%d", sig[64])
+		case "r":
+			value = hexutil.Encode(sig[:32])
+		case "s":
+			value = hexutil.Encode(sig[32:64])
+		default:
+			value = hexutil.Encode(sig)
+		}
+
+		result = strings.Replace(result, placeholder, value, 1)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// mailTypedData is the "Mail" example from the EIP-712 specification
+const mailTypedData = `{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "version", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],
+    "Person": [
+      {"name": "name", "type": "string"},
+      {"name": "wallet", "type": "address"}
+    ],
+    "Mail": [
+      {"name": "from", "type": "Person"},
+      {"name": "to", "type": "Person"},
+      {"name": "contents", "type": "string"}
+    ]
+  },
+  "primaryType": "Mail",
+  "domain": {
+    "name": "Ether Mail",
+    "version": "1",
+    "chainId": 1,
+    "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+  },
+  "message": {
+    "from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+    "to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+    "contents": "Hello, Bob!"
+  }
+}`
+
+func TestSignTypedDataMail(t *testing.T) {
+	// The specification signs with keccak256("cow"), whose address is the Cow wallet
+	key, err := crypto.ToECDSA(crypto.Keccak256([]byte("cow")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	typedData, err := ParseTypedData([]byte(mailTypedData))
+	if err != nil {
+		t.Fatalf("ParseTypedData: %v", err)
+	}
+	sig, err := SignTypedData(key, typedData)
+	if err != nil {
+		t.Fatalf("SignTypedData: %v", err)
+	}
+
+	const (
+		wantR = "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"
+		wantS = "0x07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562"
+		wantV = 28
+	)
+	if got := hexutil.Encode(sig[:32]); got != wantR {
+		t.Errorf("r = %s, want %s", got, wantR)
+	}
+	if got := hexutil.Encode(sig[32:64]); got != wantS {
+		t.Errorf("s = %s, want %s", got, wantS)
+	}
+	if sig[64] != wantV {
+		t.Errorf("v = %d, want %d", sig[64], wantV)
+	}
+}
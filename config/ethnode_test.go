@@ -0,0 +1,140 @@
+package config
+
+import (
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// testChainID is the chain ID reported by fakeEthNode
+const testChainID = 31415926
+
+// testKey is a fixed sender key for transactions sent to a fakeEthNode
+const testKey = "0xb71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291"
+
+// fakeEthNode is an in-memory Ethereum JSON-RPC node. Signed transactions are mined at once
+// with a successful receipt; its pending nonce never advances, like a node whose mempool lags.
+type fakeEthNode struct {
+	mu   sync.Mutex
+	sent []*types.Transaction
+	// failSelectors makes gas estimation fail for calls with these 4-byte selectors
+	failSelectors map[string]bool
+	// unmined leaves every transaction pending, with no receipt
+	unmined bool
+}
+
+// fakeCallArgs is the part of an eth_call or eth_estimateGas request the node looks at
+type fakeCallArgs struct {
+	To    *common.Address `json:"to"`
+	Input hexutil.Bytes   `json:"input"`
+}
+
+// newFakeEthNode serves a fakeEthNode over HTTP and returns it with its URL
+func newFakeEthNode(t *testing.T) (*fakeEthNode, string) {
+	t.Helper()
+
+	node := &fakeEthNode{failSelectors: make(map[string]bool)}
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", node); err != nil {
+		t.Fatalf("failed to register eth service: %v", err)
+	}
+	if err := server.RegisterName("net", fakeNetService{}); err != nil {
+		t.Fatalf("failed to register net service: %v", err)
+	}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+	return node, httpServer.URL
+}
+
+// failMethod makes gas estimation fail for calls to the method with signature sig
+func (n *fakeEthNode) failMethod(sig string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.failSelectors[string(selector(sig))] = true
+}
+
+// transactions returns the transactions sent to the node so far
+func (n *fakeEthNode) transactions() []*types.Transaction {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]*types.Transaction(nil), n.sent...)
+}
+
+func (n *fakeEthNode) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(testChainID))
+}
+
+func (n *fakeEthNode) BlockNumber() hexutil.Uint64 {
+	return 1
+}
+
+func (n *fakeEthNode) GetTransactionCount(addr common.Address, block string) hexutil.Uint64 {
+	return 0
+}
+
+func (n *fakeEthNode) GasPrice() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(100))
+}
+
+func (n *fakeEthNode) EstimateGas(args fakeCallArgs) (hexutil.Uint64, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if len(args.Input) >= 4 && n.failSelectors[string(args.Input[:4])] {
+		return 0, errors.New("execution reverted")
+	}
+	return 100000, nil
+}
+
+func (n *fakeEthNode) SendRawTransaction(data hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return common.Hash{}, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sent = append(n.sent, tx)
+	return tx.Hash(), nil
+}
+
+func (n *fakeEthNode) GetTransactionReceipt(hash common.Hash) (*types.Receipt, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.unmined {
+		return nil, nil
+	}
+	for _, tx := range n.sent {
+		if tx.Hash() == hash {
+			return &types.Receipt{
+				Status:      types.ReceiptStatusSuccessful,
+				TxHash:      hash,
+				GasUsed:     tx.Gas(),
+				Logs:        []*types.Log{},
+				BlockNumber: big.NewInt(1),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// fakeNetService answers net_version for fakeEthNode
+type fakeNetService struct{}
+
+func (fakeNetService) Version() string {
+	return big.NewInt(testChainID).String()
+}
+
+// selector returns the 4-byte selector of the method with signature sig
+func selector(sig string) []byte {
+	return crypto.Keccak256([]byte(sig))[:4]
+}
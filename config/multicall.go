@@ -0,0 +1,82 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const multicall3ABI = `[{"inputs":[{"components":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bool","name":"allowFailure","type":"bool"},{"internalType":"bytes","name":"callData","type":"bytes"}],"internalType":"struct Multicall3.Call3[]","name":"calls","type":"tuple[]"}],"name":"aggregate3","outputs":[{"components":[{"internalType":"bool","name":"success","type":"bool"},{"internalType":"bytes","name":"returnData","type":"bytes"}],"internalType":"struct Multicall3.Result[]","name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"}]`
+
+var multicall3 = mustParseABI(multicall3ABI)
+
+// Call3 is a single call aggregated through Multicall3.aggregate3
+type Call3 struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+// Call3Result is the per-call outcome returned by Multicall3.aggregate3
+type Call3Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// EncodeAggregate3 builds the aggregate3 calldata for the given calls
+func EncodeAggregate3(calls []Call3) ([]byte, error) {
+	data, err := multicall3.Pack("aggregate3", calls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack aggregate3: %w", err)
+	}
+	return data, nil
+}
+
+// DecodeAggregate3 unpacks the return data of an aggregate3 call
+func DecodeAggregate3(data []byte) ([]Call3Result, error) {
+	out, err := multicall3.Unpack("aggregate3", data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack aggregate3 result: %w", err)
+	}
+	return *abi.ConvertType(out[0], new([]Call3Result)).(*[]Call3Result), nil
+}
+
+// Aggregate3 runs all calls in a single eth_call against the Multicall3 contract at block (nil = latest)
+func Aggregate3(ctx context.Context, client *ethclient.Client, multicallAddress common.Address, calls []Call3, block *big.Int) ([]Call3Result, error) {
+	data, err := EncodeAggregate3(calls)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.CallContract(ctx, ethereum.CallMsg{
+		To:   &multicallAddress,
+		Data: data,
+	}, block)
+	if err != nil {
+		return nil, fmt.Errorf("multicall failed: %w", err)
+	}
+
+	results, err := DecodeAggregate3(result)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(calls) {
+		return nil, fmt.Errorf("multicall returned %d results for %d calls", len(results), len(calls))
+	}
+
+	return results, nil
+}
+
+func mustParseABI(abiJSON string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in ABI: %v", err))
+	}
+	return parsed
+}
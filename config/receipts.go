@@ -0,0 +1,64 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ReceiptResult is the outcome of waiting for one submitted transaction
+type ReceiptResult struct {
+	Hash    common.Hash
+	Receipt *types.Receipt
+	Err     error
+}
+
+// Confirmed reports whether the transaction was mined and succeeded
+func (r ReceiptResult) Confirmed() bool {
+	return r.Err == nil && r.Receipt != nil && r.Receipt.Status == types.ReceiptStatusSuccessful
+}
+
+// Reverted reports whether the transaction was mined but reverted
+func (r ReceiptResult) Reverted() bool {
+	return r.Err == nil && r.Receipt != nil && r.Receipt.Status != types.ReceiptStatusSuccessful
+}
+
+// WaitForReceipts waits for every transaction concurrently and returns the results in the
+// order of hashes. Each wait uses PollReceipt, so a transaction still unmined after the receipt
+// timeout gets ErrReceiptTimeout, and one whose lookups keep failing gets the RPC error.
+func WaitForReceipts(ctx context.Context, client *ethclient.Client, hashes []common.Hash) []ReceiptResult {
+	results := make([]ReceiptResult, len(hashes))
+
+	var wg sync.WaitGroup
+	for i, hash := range hashes {
+		wg.Add(1)
+		go func(i int, hash common.Hash) {
+			defer wg.Done()
+			receipt, err := pollReceipt(ctx, client, hash)
+			results[i] = ReceiptResult{Hash: hash, Receipt: receipt, Err: err}
+		}(i, hash)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// pollReceipt waits for one receipt. Only ethereum.NotFound means the transaction is not mined
+// yet; any other lookup error counts toward PollReceipt's error limit.
+func pollReceipt(ctx context.Context, client *ethclient.Client, hash common.Hash) (*types.Receipt, error) {
+	return PollReceipt(ctx, func(ctx context.Context) (*types.Receipt, bool, error) {
+		receipt, err := client.TransactionReceipt(ctx, hash)
+		if errors.Is(err, ethereum.NotFound) || (err == nil && receipt == nil) {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return receipt, true, nil
+	})
+}
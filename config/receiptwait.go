@@ -0,0 +1,87 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// DefaultReceiptTimeout is how long a receipt wait lasts before giving up on the transaction
+const DefaultReceiptTimeout = 60 * time.Second
+
+const (
+	// receiptPollBase and receiptPollMax bound the exponential backoff between receipt polls
+	receiptPollBase = 500 * time.Millisecond
+	receiptPollMax  = 8 * time.Second
+
+	// maxReceiptErrors is how many RPC errors in a row a receipt wait tolerates before failing
+	maxReceiptErrors = 3
+)
+
+// ErrReceiptTimeout is returned when a transaction is still unmined after the wait budget
+var ErrReceiptTimeout = errors.New("transaction not confirmed after waiting")
+
+// receiptTimeout is the wait budget used by PollReceipt
+var receiptTimeout = DefaultReceiptTimeout
+
+// SetReceiptTimeout sets the total time receipt waits allow (0 keeps the default)
+func SetReceiptTimeout(d time.Duration) {
+	if d <= 0 {
+		d = DefaultReceiptTimeout
+	}
+	receiptTimeout = d
+}
+
+// PollReceipt calls fetch with exponential backoff and jitter until it reports the receipt
+// found, ctx is done, or the receipt timeout elapses. fetch returns found=false and a nil error
+// while the transaction is not mined yet; an error means the RPC itself failed, and is returned
+// once it repeats maxReceiptErrors times in a row instead of being waited out.
+func PollReceipt[T any](ctx context.Context, fetch func(context.Context) (T, bool, error)) (T, error) {
+	var zero T
+	deadline := time.Now().Add(receiptTimeout)
+
+	var failures int
+	for attempt := 0; ; attempt++ {
+		receipt, found, err := fetch(ctx)
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return zero, ctx.Err()
+			}
+			failures++
+			if failures >= maxReceiptErrors {
+				return zero, fmt.Errorf("failed to get transaction receipt: %w", err)
+			}
+		case found:
+			return receipt, nil
+		default:
+			failures = 0
+		}
+
+		delay := receiptBackoff(attempt)
+		if remaining := time.Until(deadline); remaining <= 0 {
+			return zero, ErrReceiptTimeout
+		} else if delay > remaining {
+			delay = remaining
+		}
+
+		select {
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// receiptBackoff is the delay after poll attempt n: doubling from receiptPollBase up to
+// receiptPollMax, randomized by ±20% so concurrent waits do not poll in lockstep
+func receiptBackoff(attempt int) time.Duration {
+	delay := receiptPollMax
+	if attempt < 5 {
+		delay = min(receiptPollBase<<attempt, receiptPollMax)
+	}
+	jitter := time.Duration(rand.Int64N(int64(delay)/5*2+1)) - delay/5
+	return delay + jitter
+}
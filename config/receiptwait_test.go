@@ -0,0 +1,167 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// fetchStep is what one call to a PollReceipt fetch function returns
+type fetchStep struct {
+	found bool
+	err   error
+}
+
+// withReceiptTimeout sets the receipt wait budget for the rest of the test
+func withReceiptTimeout(t *testing.T, d time.Duration) {
+	t.Helper()
+	SetReceiptTimeout(d)
+	t.Cleanup(func() { SetReceiptTimeout(DefaultReceiptTimeout) })
+}
+
+func TestPollReceipt(t *testing.T) {
+	errTransport := errors.New("connection reset by peer")
+	pending := fetchStep{}
+	found := fetchStep{found: true}
+	failing := fetchStep{err: errTransport}
+
+	tests := []struct {
+		name      string
+		steps     []fetchStep // the last step repeats
+		timeout   time.Duration
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "found immediately", steps: []fetchStep{found}, wantCalls: 1},
+		{name: "pending then found", steps: []fetchStep{pending, pending, found}, wantCalls: 3},
+		{name: "transient errors are retried", steps: []fetchStep{failing, failing, found}, wantCalls: 3},
+		{name: "persistent error is surfaced", steps: []fetchStep{failing}, wantErr: errTransport, wantCalls: maxReceiptErrors},
+		{name: "still pending after the budget", steps: []fetchStep{pending}, timeout: 50 * time.Millisecond, wantErr: ErrReceiptTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.timeout > 0 {
+				withReceiptTimeout(t, tt.timeout)
+			}
+
+			calls := 0
+			receipt, err := PollReceipt(context.Background(), func(context.Context) (string, bool, error) {
+				step := tt.steps[min(calls, len(tt.steps)-1)]
+				calls++
+				if step.found {
+					return "receipt", true, nil
+				}
+				return "", false, step.err
+			})
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("PollReceipt: %v", err)
+				}
+				if receipt != "receipt" {
+					t.Errorf("receipt = %q, want %q", receipt, "receipt")
+				}
+			}
+			if tt.wantCalls > 0 && calls != tt.wantCalls {
+				t.Errorf("fetch called %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestPollReceiptCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := PollReceipt(ctx, func(context.Context) (int, bool, error) {
+		return 0, false, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+}
+
+func TestReceiptBackoff(t *testing.T) {
+	for attempt := 0; attempt < 10; attempt++ {
+		base := receiptPollMax
+		if attempt < 5 {
+			base = min(receiptPollBase<<attempt, receiptPollMax)
+		}
+		delay := receiptBackoff(attempt)
+		if delay < base-base/5 || delay > base+base/5 {
+			t.Errorf("receiptBackoff(%d) = %v, want within 20%% of %v", attempt, delay, base)
+		}
+	}
+}
+
+// receiptService serves eth_getTransactionReceipt for WaitForReceipts tests: mined hashes get a
+// successful receipt, failing hashes an RPC error, and anything else null (not mined yet)
+type receiptService struct {
+	mined   map[common.Hash]bool
+	failing map[common.Hash]bool
+}
+
+func (s *receiptService) GetTransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	if s.failing[hash] {
+		return nil, errors.New("upstream node unavailable")
+	}
+	if !s.mined[hash] {
+		return nil, nil
+	}
+	return &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		TxHash:      hash,
+		GasUsed:     21000,
+		Logs:        []*types.Log{},
+		BlockNumber: big.NewInt(1),
+	}, nil
+}
+
+func TestWaitForReceipts(t *testing.T) {
+	withReceiptTimeout(t, 3*time.Second)
+
+	mined := common.HexToHash("0x01")
+	pending := common.HexToHash("0x02")
+	failing := common.HexToHash("0x03")
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	service := &receiptService{
+		mined:   map[common.Hash]bool{mined: true},
+		failing: map[common.Hash]bool{failing: true},
+	}
+	if err := server.RegisterName("eth", service); err != nil {
+		t.Fatalf("failed to register service: %v", err)
+	}
+	client := ethclient.NewClient(rpc.DialInProc(server))
+	defer client.Close()
+
+	results := WaitForReceipts(context.Background(), client, []common.Hash{mined, pending, failing})
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+
+	if !results[0].Confirmed() || results[0].Hash != mined {
+		t.Errorf("mined transaction: got %+v, want a confirmed receipt", results[0])
+	}
+	if !errors.Is(results[1].Err, ErrReceiptTimeout) {
+		t.Errorf("pending transaction: error = %v, want ErrReceiptTimeout", results[1].Err)
+	}
+	// A transport error is not mistaken for "not mined yet" and waited out
+	if err := results[2].Err; err == nil || errors.Is(err, ErrReceiptTimeout) || !strings.Contains(err.Error(), "upstream node unavailable") {
+		t.Errorf("failing lookup: error = %v, want the RPC error", err)
+	}
+}
@@ -0,0 +1,309 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// EncodedValue is an ABI-encoded tuple or array argument. Dynamic values (dynamic arrays, or
+// anything containing one, string, or bytes) are placed in the tail of the calldata like other
+// dynamic arguments.
+type EncodedValue struct {
+	Encoded []byte
+	Dynamic bool
+}
+
+// isTupleType reports whether t is a tuple type such as (uint256,address)
+func isTupleType(t string) bool {
+	t = strings.TrimSpace(t)
+	return strings.HasPrefix(t, "(") && strings.HasSuffix(t, ")")
+}
+
+// parseTupleComponents parses a flat tuple spec whose components are elementary types or
+// one-dimensional arrays of them. Components may be named, e.g. (uint256 amount,address to),
+// so a JSON object value can be matched by name.
+func parseTupleComponents(spec string) (abi.Arguments, error) {
+	inner := strings.TrimSpace(spec)
+	inner = inner[1 : len(inner)-1]
+	if strings.ContainsAny(inner, "()") {
+		return nil, fmt.Errorf("nested tuples are not supported: %s", spec)
+	}
+	if strings.TrimSpace(inner) == "" {
+		return nil, fmt.Errorf("empty tuple type: %s", spec)
+	}
+
+	var components abi.Arguments
+	for _, part := range strings.Split(inner, ",") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, fmt.Errorf("invalid tuple component %q in %s", strings.TrimSpace(part), spec)
+		}
+		typ, err := abi.NewType(canonicalTypeSpec(fields[0]), "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tuple component type %q: %w", fields[0], err)
+		}
+		if (typ.T == abi.SliceTy || typ.T == abi.ArrayTy) && (typ.Elem.T == abi.SliceTy || typ.Elem.T == abi.ArrayTy) {
+			return nil, fmt.Errorf("nested arrays are not supported: %s", spec)
+		}
+		component := abi.Argument{Type: typ}
+		if len(fields) == 2 {
+			component.Name = fields[1]
+		}
+		components = append(components, component)
+	}
+	return components, nil
+}
+
+// convertTupleArgument encodes a JSON array, or a JSON object keyed by component name,
+// as the tuple described by spec
+func convertTupleArgument(value, spec string) (TypedArg, error) {
+	components, err := parseTupleComponents(spec)
+	if err != nil {
+		return TypedArg{}, err
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+	var raw interface{}
+	if err := decoder.Decode(&raw); err != nil {
+		return TypedArg{}, fmt.Errorf("tuple value must be a JSON array or object: %w", err)
+	}
+
+	var elems []interface{}
+	switch v := raw.(type) {
+	case []interface{}:
+		elems = v
+	case map[string]interface{}:
+		elems = make([]interface{}, len(components))
+		for i, component := range components {
+			if component.Name == "" {
+				return TypedArg{}, fmt.Errorf("a JSON object needs named components, e.g. (uint256 amount,address to)")
+			}
+			elem, ok := v[component.Name]
+			if !ok {
+				return TypedArg{}, fmt.Errorf("tuple value is missing field %q", component.Name)
+			}
+			elems[i] = elem
+		}
+		if len(v) != len(components) {
+			return TypedArg{}, fmt.Errorf("tuple value has %d fields, %s has %d components", len(v), spec, len(components))
+		}
+	default:
+		return TypedArg{}, fmt.Errorf("tuple value must be a JSON array or object")
+	}
+	if len(elems) != len(components) {
+		return TypedArg{}, fmt.Errorf("tuple value has %d elements, %s has %d components", len(elems), spec, len(components))
+	}
+
+	values := make([]interface{}, len(components))
+	dynamic := false
+	typeNames := make([]string, len(components))
+	for i, component := range components {
+		values[i], err = tupleComponentValue(component.Type, elems[i])
+		if err != nil {
+			return TypedArg{}, fmt.Errorf("tuple component %d: %w", i, err)
+		}
+
+		typeNames[i] = component.Type.String()
+		if isDynamicType(component.Type) {
+			dynamic = true
+		}
+	}
+
+	encoded, err := components.Pack(values...)
+	if err != nil {
+		return TypedArg{}, fmt.Errorf("failed to encode tuple: %w", err)
+	}
+
+	return TypedArg{
+		Type:  "(" + strings.Join(typeNames, ",") + ")",
+		Value: EncodedValue{Encoded: encoded, Dynamic: dynamic},
+	}, nil
+}
+
+// tupleComponentValue converts one decoded JSON tuple element to the Go value abi.Pack expects
+func tupleComponentValue(t abi.Type, elem interface{}) (interface{}, error) {
+	if t.T == abi.SliceTy || t.T == abi.ArrayTy {
+		elems, ok := elem.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s value must be a JSON array", t)
+		}
+		return arrayPackValue(t, elems)
+	}
+
+	str, err := jsonScalarString(elem)
+	if err != nil {
+		return nil, err
+	}
+	converted, err := convertArgument(str, t.String())
+	if err != nil {
+		return nil, err
+	}
+	return toPackValue(t, converted)
+}
+
+// isDynamicType reports whether values of t are encoded in the tail: strings, bytes, dynamic
+// arrays, and fixed arrays or tuples with a dynamic component
+func isDynamicType(t abi.Type) bool {
+	switch t.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy:
+		return true
+	case abi.ArrayTy:
+		return isDynamicType(*t.Elem)
+	case abi.TupleTy:
+		for _, elem := range t.TupleElems {
+			if isDynamicType(*elem) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// canonicalTypeSpec applies canonicalABIType to the element type of an array spec
+func canonicalTypeSpec(spec string) string {
+	if open := strings.Index(spec, "["); open > 0 {
+		return canonicalABIType(spec[:open]) + spec[open:]
+	}
+	return canonicalABIType(spec)
+}
+
+// isArrayType reports whether t is an array type such as uint256[] or address[3]
+func isArrayType(t string) bool {
+	return strings.HasSuffix(strings.TrimSpace(t), "]")
+}
+
+// convertArrayArgument encodes a JSON array of scalars as the one-dimensional array type spec
+func convertArrayArgument(value, spec string) (TypedArg, error) {
+	spec = strings.TrimSpace(spec)
+	open := strings.LastIndex(spec, "[")
+	if open <= 0 {
+		return TypedArg{}, fmt.Errorf("invalid array type: %s", spec)
+	}
+	elemSpec := spec[:open]
+	if isArrayType(elemSpec) || isTupleType(elemSpec) || !IsSolidityType(elemSpec) {
+		return TypedArg{}, fmt.Errorf("unsupported array element type %s (nested arrays and tuple arrays are not supported)", elemSpec)
+	}
+
+	typ, err := abi.NewType(canonicalABIType(elemSpec)+spec[open:], "", nil)
+	if err != nil {
+		return TypedArg{}, fmt.Errorf("invalid array type %s: %w", spec, err)
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+	var elems []interface{}
+	if err := decoder.Decode(&elems); err != nil {
+		return TypedArg{}, fmt.Errorf("array value must be a JSON array: %w", err)
+	}
+
+	packed, err := arrayPackValue(typ, elems)
+	if err != nil {
+		return TypedArg{}, err
+	}
+
+	encoded, err := abi.Arguments{{Type: typ}}.Pack(packed)
+	if err != nil {
+		return TypedArg{}, fmt.Errorf("failed to encode array: %w", err)
+	}
+
+	// Packed alone, a dynamic value is preceded by its offset word, which the caller's
+	// encoding supplies instead
+	dynamic := isDynamicType(typ)
+	if dynamic {
+		encoded = encoded[32:]
+	}
+
+	return TypedArg{Type: typ.String(), Value: EncodedValue{Encoded: encoded, Dynamic: dynamic}}, nil
+}
+
+// arrayPackValue converts decoded JSON array elements to the Go slice or array abi.Pack
+// expects for the one-dimensional array type typ
+func arrayPackValue(typ abi.Type, elems []interface{}) (interface{}, error) {
+	var out reflect.Value
+	if typ.T == abi.ArrayTy {
+		if len(elems) != typ.Size {
+			return nil, fmt.Errorf("array value has %d elements, %s has %d", len(elems), typ, typ.Size)
+		}
+		out = reflect.New(typ.GetType()).Elem()
+	} else {
+		out = reflect.MakeSlice(typ.GetType(), len(elems), len(elems))
+	}
+
+	for i, elem := range elems {
+		str, err := jsonScalarString(elem)
+		if err != nil {
+			return nil, fmt.Errorf("array element %d: %w", i, err)
+		}
+		converted, err := convertArgument(str, typ.Elem.String())
+		if err != nil {
+			return nil, fmt.Errorf("array element %d: %w", i, err)
+		}
+		packed, err := toPackValue(*typ.Elem, converted)
+		if err != nil {
+			return nil, fmt.Errorf("array element %d: %w", i, err)
+		}
+		out.Index(i).Set(reflect.ValueOf(packed))
+	}
+	return out.Interface(), nil
+}
+
+// jsonScalarString renders a decoded JSON scalar in the string form convertArgument accepts
+func jsonScalarString(v interface{}) (string, error) {
+	switch val := v.(type) {
+	case string:
+		return val, nil
+	case json.Number:
+		return val.String(), nil
+	case bool:
+		if val {
+			return "true", nil
+		}
+		return "false", nil
+	default:
+		return "", fmt.Errorf("unsupported JSON value %v (nested arrays and objects are not supported)", v)
+	}
+}
+
+// toPackValue converts a value from convertArgument to the Go type abi.Pack expects for t
+func toPackValue(t abi.Type, v interface{}) (interface{}, error) {
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		n, ok := v.(*big.Int)
+		if !ok {
+			return nil, fmt.Errorf("expected an integer for %s", t)
+		}
+		if t.Size > 64 {
+			return n, nil
+		}
+		out := reflect.New(t.GetType()).Elem()
+		if t.T == abi.UintTy {
+			if n.Sign() < 0 || n.BitLen() > t.Size {
+				return nil, fmt.Errorf("%s out of range for %s", n, t)
+			}
+			out.SetUint(n.Uint64())
+		} else {
+			if !n.IsInt64() || out.OverflowInt(n.Int64()) {
+				return nil, fmt.Errorf("%s out of range for %s", n, t)
+			}
+			out.SetInt(n.Int64())
+		}
+		return out.Interface(), nil
+	case abi.FixedBytesTy:
+		b, ok := v.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("expected bytes for %s", t)
+		}
+		out := reflect.New(t.GetType()).Elem()
+		reflect.Copy(out, reflect.ValueOf(bytes.Clone(b)))
+		return out.Interface(), nil
+	default:
+		return v, nil
+	}
+}
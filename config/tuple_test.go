@@ -0,0 +1,238 @@
+package config
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestConvertTupleArgument(t *testing.T) {
+	to := common.HexToAddress("0x000000000000000000000000000000000000000a")
+
+	tests := []struct {
+		name        string
+		value       string
+		spec        string
+		wantType    string
+		wantDynamic bool
+		types       []string
+		values      []interface{}
+	}{
+		{
+			name:     "JSON array",
+			value:    `[5, "0x000000000000000000000000000000000000000a"]`,
+			spec:     "(uint256,address)",
+			wantType: "(uint256,address)",
+			types:    []string{"uint256", "address"},
+			values:   []interface{}{big.NewInt(5), to},
+		},
+		{
+			name:     "JSON object with named components",
+			value:    `{"to": "0x000000000000000000000000000000000000000a", "amount": "5"}`,
+			spec:     "(uint256 amount,address to)",
+			wantType: "(uint256,address)",
+			types:    []string{"uint256", "address"},
+			values:   []interface{}{big.NewInt(5), to},
+		},
+		{
+			name:        "dynamic component",
+			value:       `[1, "memo", true]`,
+			spec:        "(uint64,string,bool)",
+			wantType:    "(uint64,string,bool)",
+			wantDynamic: true,
+			types:       []string{"uint64", "string", "bool"},
+			values:      []interface{}{uint64(1), "memo", true},
+		},
+		{
+			name:        "dynamic array component",
+			value:       `[7, ["0x000000000000000000000000000000000000000a", "0x000000000000000000000000000000000000000b"]]`,
+			spec:        "(uint256,address[])",
+			wantType:    "(uint256,address[])",
+			wantDynamic: true,
+			types:       []string{"uint256", "address[]"},
+			values:      []interface{}{big.NewInt(7), []common.Address{to, common.HexToAddress("0xb")}},
+		},
+		{
+			name:     "static array component",
+			value:    `[["1", "2"], true]`,
+			spec:     "(uint16[2],bool)",
+			wantType: "(uint16[2],bool)",
+			types:    []string{"uint16[2]", "bool"},
+			values:   []interface{}{[2]uint16{1, 2}, true},
+		},
+		{
+			name:        "static array of a dynamic type",
+			value:       `[["a", "b"]]`,
+			spec:        "(string[2])",
+			wantType:    "(string[2])",
+			wantDynamic: true,
+			types:       []string{"string[2]"},
+			values:      []interface{}{[2]string{"a", "b"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			arg, err := ConvertTypedArgument(tt.value, tt.spec)
+			if err != nil {
+				t.Fatalf("ConvertTypedArgument: %v", err)
+			}
+			if arg.Type != tt.wantType {
+				t.Errorf("type = %s, want %s", arg.Type, tt.wantType)
+			}
+
+			encoded, ok := arg.Value.(EncodedValue)
+			if !ok {
+				t.Fatalf("value is %T, want EncodedValue", arg.Value)
+			}
+			if encoded.Dynamic != tt.wantDynamic {
+				t.Errorf("dynamic = %v, want %v", encoded.Dynamic, tt.wantDynamic)
+			}
+			if want := packWithABI(t, tt.types, tt.values); !bytes.Equal(encoded.Encoded, want) {
+				t.Errorf("encoding mismatch\n got: %x\nwant: %x", encoded.Encoded, want)
+			}
+		})
+	}
+}
+
+func TestTupleCallData(t *testing.T) {
+	arg, err := ConvertTypedArgument(`[5, "0x000000000000000000000000000000000000000a"]`, "(uint256,address)")
+	if err != nil {
+		t.Fatalf("ConvertTypedArgument: %v", err)
+	}
+
+	callData, err := (&ContractWrapper{}).buildCallData("submit", []interface{}{arg})
+	if err != nil {
+		t.Fatalf("buildCallData: %v", err)
+	}
+
+	selector := crypto.Keccak256([]byte("submit((uint256,address))"))[:4]
+	if !bytes.Equal(callData[:4], selector) {
+		t.Errorf("selector = %x, want %x for submit((uint256,address))", callData[:4], selector)
+	}
+
+	// A static tuple is encoded in place, without an offset word
+	want := packWithABI(t, []string{"uint256", "address"}, []interface{}{big.NewInt(5), common.HexToAddress("0xa")})
+	if !bytes.Equal(callData[4:], want) {
+		t.Errorf("arguments mismatch\n got: %x\nwant: %x", callData[4:], want)
+	}
+}
+
+func TestDynamicTupleCallData(t *testing.T) {
+	arg, err := ConvertTypedArgument(`[7, ["0x000000000000000000000000000000000000000a"]]`, "(uint256,address[])")
+	if err != nil {
+		t.Fatalf("ConvertTypedArgument: %v", err)
+	}
+
+	callData, err := (&ContractWrapper{}).buildCallData("submit", []interface{}{arg, big.NewInt(9)})
+	if err != nil {
+		t.Fatalf("buildCallData: %v", err)
+	}
+
+	selector := crypto.Keccak256([]byte("submit((uint256,address[]),uint256)"))[:4]
+	if !bytes.Equal(callData[:4], selector) {
+		t.Errorf("selector = %x, want %x for submit((uint256,address[]),uint256)", callData[:4], selector)
+	}
+
+	// A dynamic tuple goes in the tail, behind an offset word
+	tupleType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+		{Name: "amount", Type: "uint256"},
+		{Name: "targets", Type: "address[]"},
+	})
+	if err != nil {
+		t.Fatalf("abi.NewType: %v", err)
+	}
+	uintType, _ := abi.NewType("uint256", "", nil)
+	want, err := abi.Arguments{{Type: tupleType}, {Type: uintType}}.Pack(struct {
+		Amount  *big.Int
+		Targets []common.Address
+	}{big.NewInt(7), []common.Address{common.HexToAddress("0xa")}}, big.NewInt(9))
+	if err != nil {
+		t.Fatalf("abi Pack: %v", err)
+	}
+	if !bytes.Equal(callData[4:], want) {
+		t.Errorf("arguments mismatch\n got: %x\nwant: %x", callData[4:], want)
+	}
+}
+
+func TestConvertTupleArgumentErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		spec    string
+		wantErr string
+	}{
+		{name: "too few elements", value: `[1]`, spec: "(uint256,address)", wantErr: "has 1 elements"},
+		{name: "missing field", value: `{"amount": 1}`, spec: "(uint256 amount,address to)", wantErr: `missing field "to"`},
+		{name: "object without names", value: `{"amount": 1}`, spec: "(uint256)", wantErr: "named components"},
+		{name: "nested tuple", value: `[[1]]`, spec: "((uint256))", wantErr: "nested tuples"},
+		{name: "nested array component", value: `[[[1]]]`, spec: "(uint256[][])", wantErr: "nested arrays"},
+		{name: "array component not an array", value: `[1]`, spec: "(uint256[])", wantErr: "must be a JSON array"},
+		{name: "not JSON", value: `1,2`, spec: "(uint256,uint256)", wantErr: "JSON array or object"},
+		{name: "component out of range", value: `[256]`, spec: "(uint8)", wantErr: "out of range for uint8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ConvertTypedArgument(tt.value, tt.spec)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConvertArrayArgument(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		spec        string
+		wantDynamic bool
+		types       []string
+		values      []interface{}
+	}{
+		{
+			name:        "dynamic uint array",
+			value:       `[1, 2, 3]`,
+			spec:        "uint256[]",
+			wantDynamic: true,
+			types:       []string{"uint256[]"},
+			values:      []interface{}{[]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}},
+		},
+		{
+			name:   "fixed address array",
+			value:  `["0x0000000000000000000000000000000000000001", "0x0000000000000000000000000000000000000002"]`,
+			spec:   "address[2]",
+			types:  []string{"address[2]"},
+			values: []interface{}{[2]common.Address{common.HexToAddress("0x1"), common.HexToAddress("0x2")}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			arg, err := ConvertTypedArgument(tt.value, tt.spec)
+			if err != nil {
+				t.Fatalf("ConvertTypedArgument: %v", err)
+			}
+
+			encoded := arg.Value.(EncodedValue)
+			if encoded.Dynamic != tt.wantDynamic {
+				t.Errorf("dynamic = %v, want %v", encoded.Dynamic, tt.wantDynamic)
+			}
+
+			// Through encodeArguments the array gets the same layout as the ABI packer gives it
+			got, err := encodeArguments([]interface{}{arg})
+			if err != nil {
+				t.Fatalf("encodeArguments: %v", err)
+			}
+			if want := packWithABI(t, tt.types, tt.values); !bytes.Equal(got, want) {
+				t.Errorf("encoding mismatch\n got: %x\nwant: %x", got, want)
+			}
+		})
+	}
+}